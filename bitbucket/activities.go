@@ -0,0 +1,294 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+type activity struct {
+	ID               int            `json:"id"`
+	CreatedDate      int64          `json:"createdDate"`
+	User             *user          `json:"user"`
+	Action           string         `json:"action"`
+	CommentAction    string         `json:"commentAction"`
+	Comment          *comment       `json:"comment"`
+	CommentAnchor    *commentAnchor `json:"commentAnchor"`
+	Diff             *diff          `json:"diff"`
+	Commit           *commit        `json:"commit"`
+	AddedReviewers   []*user        `json:"addedReviewers"`
+	RemovedReviewers []*user        `json:"removedReviewers"`
+	Removed          *struct {
+		Total int `json:"total"`
+	} `json:"removed"`
+}
+
+type comment struct {
+	ID          int        `json:"id"`
+	Text        string     `json:"text"`
+	Author      *user      `json:"author"`
+	CreatedDate int64      `json:"createdDate"`
+	UpdatedDate int64      `json:"updatedDate"`
+	Comments    []*comment `json:"comments"`
+}
+
+type commentAnchor struct {
+	Line     int    `json:"line"`
+	LineType string `json:"lineType"`
+	FileType string `json:"fileType"`
+	Path     string `json:"path"`
+}
+
+// getActivities gets the activities of the pull request, the oldest first.
+// The activities are cached since comments, events and reviews are built from them.
+func (c *client) getActivities(repo string, pullNumber int) ([]*activity, error) {
+	key := fmt.Sprintf("%s#%d", repo, pullNumber)
+	c.mu.Lock()
+	as, ok := c.activities[key]
+	c.mu.Unlock()
+	if ok {
+		return as, nil
+	}
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.getPaged(c.url(fmt.Sprintf("%s/pull-requests/%d/activities", path, pullNumber)), func(bs json.RawMessage) error {
+		var xs []*activity
+		if err := json.Unmarshal(bs, &xs); err != nil {
+			return err
+		}
+		as = append(as, xs...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(as, func(i, j int) bool {
+		return as[i].CreatedDate < as[j].CreatedDate
+	})
+	c.mu.Lock()
+	c.activities[key] = as
+	c.mu.Unlock()
+	return as, nil
+}
+
+// flattenComments collects the comment and its replies, depth-first.
+func flattenComments(x *comment, parentID int, f func(*comment, int)) {
+	f(x, parentID)
+	for _, y := range x.Comments {
+		flattenComments(y, x.ID, f)
+	}
+}
+
+func deletedCommentIDs(as []*activity) map[int]bool {
+	ids := make(map[int]bool)
+	for _, a := range as {
+		if a.Action == "COMMENTED" && a.CommentAction == "DELETED" && a.Comment != nil {
+			ids[a.Comment.ID] = true
+		}
+	}
+	return ids
+}
+
+func (c *client) commentURL(repo string, pullNumber, commentID int) string {
+	return fmt.Sprintf("%s/pull-requests/%d/overview?commentId=%d", c.repoURL(repo), pullNumber, commentID)
+}
+
+// ListComments lists the comments of the pull request which are not attached
+// to the diff. The replies are flattened since GitHub comments have no thread.
+func (c *client) ListComments(repo string, issueNumber int) github.Comments {
+	cs := make(chan interface{})
+	go func() {
+		defer close(cs)
+		as, err := c.getActivities(repo, issueNumber)
+		if err != nil {
+			cs <- fmt.Errorf("ListComments %s/pull-requests/%d: %w", repo, issueNumber, err)
+			return
+		}
+		deleted := deletedCommentIDs(as)
+		var xs []*github.Comment
+		for _, a := range as {
+			if a.Action != "COMMENTED" || a.CommentAction != "ADDED" ||
+				a.Comment == nil || a.CommentAnchor != nil {
+				continue
+			}
+			flattenComments(a.Comment, 0, func(x *comment, _ int) {
+				if deleted[x.ID] {
+					return
+				}
+				xs = append(xs, &github.Comment{
+					Body:      x.Text,
+					HTMLURL:   c.commentURL(repo, issueNumber, x.ID),
+					User:      c.toUser(x.Author),
+					CreatedAt: formatTime(x.CreatedDate),
+					UpdatedAt: formatTime(x.UpdatedDate),
+				})
+			})
+		}
+		sort.SliceStable(xs, func(i, j int) bool {
+			return xs[i].CreatedAt < xs[j].CreatedAt
+		})
+		for _, x := range xs {
+			cs <- x
+		}
+	}()
+	return github.Comments(cs)
+}
+
+// ListEvents lists the events converted from the activities.
+func (c *client) ListEvents(repo string, issueNumber int) github.Events {
+	es := make(chan interface{})
+	go func() {
+		defer close(es)
+		as, err := c.getActivities(repo, issueNumber)
+		if err != nil {
+			es <- fmt.Errorf("ListEvents %s/pull-requests/%d: %w", repo, issueNumber, err)
+			return
+		}
+		for _, a := range as {
+			for _, e := range c.toEvents(a) {
+				es <- e
+			}
+		}
+	}()
+	return github.Events(es)
+}
+
+func (c *client) toEvents(a *activity) []*github.Event {
+	newEvent := func(event string) *github.Event {
+		return &github.Event{
+			ID:        a.ID,
+			Actor:     c.toUser(a.User),
+			Event:     event,
+			CreatedAt: formatTime(a.CreatedDate),
+		}
+	}
+	switch a.Action {
+	case "MERGED":
+		if a.Commit == nil {
+			return []*github.Event{newEvent("closed")}
+		}
+		e := newEvent("merged")
+		e.CommitID = a.Commit.ID
+		return []*github.Event{e, newEvent("closed")}
+	case "DECLINED":
+		return []*github.Event{newEvent("closed")}
+	case "REOPENED":
+		return []*github.Event{newEvent("reopened")}
+	case "RESCOPED":
+		if a.Removed != nil && a.Removed.Total > 0 {
+			return []*github.Event{newEvent("head_ref_force_pushed")}
+		}
+	case "UPDATED":
+		var xs []*github.Event
+		for _, u := range a.AddedReviewers {
+			e := newEvent("review_requested")
+			e.Reviewer = c.toUser(u)
+			xs = append(xs, e)
+		}
+		for _, u := range a.RemovedReviewers {
+			e := newEvent("review_request_removed")
+			e.Reviewer = c.toUser(u)
+			xs = append(xs, e)
+		}
+		return xs
+	}
+	return nil
+}
+
+// ListReviews lists the approvals and the requests for changes (needs work).
+func (c *client) ListReviews(repo string, pullNumber int) github.Reviews {
+	rs := make(chan interface{})
+	go func() {
+		defer close(rs)
+		as, err := c.getActivities(repo, pullNumber)
+		if err != nil {
+			rs <- fmt.Errorf("ListReviews %s/pull-requests/%d: %w", repo, pullNumber, err)
+			return
+		}
+		for _, a := range as {
+			if r := c.toReview(repo, pullNumber, a); r != nil {
+				rs <- r
+			}
+		}
+	}()
+	return github.Reviews(rs)
+}
+
+func (c *client) toReview(repo string, pullNumber int, a *activity) *github.Review {
+	var state github.ReviewState
+	switch a.Action {
+	case "APPROVED":
+		state = github.ReviewStateApproved
+	case "REVIEWED":
+		state = github.ReviewStateChangesRequested
+	default:
+		return nil
+	}
+	return &github.Review{
+		ID:          a.ID,
+		State:       state,
+		HTMLURL:     fmt.Sprintf("%s/pull-requests/%d/overview", c.repoURL(repo), pullNumber),
+		User:        c.toUser(a.User),
+		SubmittedAt: formatTime(a.CreatedDate),
+	}
+}
+
+// GetReview gets the review.
+func (c *client) GetReview(repo string, pullNumber, reviewID int) (*github.Review, error) {
+	as, err := c.getActivities(repo, pullNumber)
+	if err != nil {
+		return nil, fmt.Errorf("GetReview %s/pull-requests/%d: %w", repo, pullNumber, err)
+	}
+	for _, a := range as {
+		if a.ID == reviewID {
+			if r := c.toReview(repo, pullNumber, a); r != nil {
+				return r, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("GetReview %s/pull-requests/%d: review not found: %d", repo, pullNumber, reviewID)
+}
+
+// ListReviewComments lists the comments attached to the diff.
+func (c *client) ListReviewComments(repo string, pullNumber int) github.ReviewComments {
+	cs := make(chan interface{})
+	go func() {
+		defer close(cs)
+		as, err := c.getActivities(repo, pullNumber)
+		if err != nil {
+			cs <- fmt.Errorf("ListReviewComments %s/pull-requests/%d: %w", repo, pullNumber, err)
+			return
+		}
+		deleted := deletedCommentIDs(as)
+		for _, a := range as {
+			if a.Action != "COMMENTED" || a.CommentAction != "ADDED" ||
+				a.Comment == nil || a.CommentAnchor == nil || deleted[a.Comment.ID] {
+				continue
+			}
+			var diffHunk string
+			if a.Diff != nil {
+				diffHunk = buildDiffHunk(a.Diff, a.CommentAnchor)
+			}
+			flattenComments(a.Comment, 0, func(x *comment, parentID int) {
+				if deleted[x.ID] {
+					return
+				}
+				cs <- &github.ReviewComment{
+					ID:          x.ID,
+					Path:        a.CommentAnchor.Path,
+					Body:        x.Text,
+					DiffHunk:    diffHunk,
+					HTMLURL:     c.commentURL(repo, pullNumber, x.ID),
+					User:        c.toUser(x.Author),
+					InReplyToID: parentID,
+					CreatedAt:   formatTime(x.CreatedDate),
+					UpdatedAt:   formatTime(x.UpdatedDate),
+				}
+			})
+		}
+	}()
+	return github.ReviewComments(cs)
+}
@@ -0,0 +1,149 @@
+package bitbucket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+const testActivities = `{
+  "isLastPage": true,
+  "values": [
+    {
+      "id": 5,
+      "createdDate": 1574082060000,
+      "user": {"name": "user2", "slug": "user2"},
+      "action": "MERGED",
+      "commit": {"id": "0123456789abcdef0123456789abcdef01234567"}
+    },
+    {
+      "id": 4,
+      "createdDate": 1574082000000,
+      "user": {"name": "user2", "slug": "user2"},
+      "action": "APPROVED"
+    },
+    {
+      "id": 3,
+      "createdDate": 1574081000000,
+      "user": {"name": "user2", "slug": "user2"},
+      "action": "COMMENTED",
+      "commentAction": "ADDED",
+      "comment": {
+        "id": 20,
+        "text": "Inline comment",
+        "author": {"name": "user2", "slug": "user2"},
+        "createdDate": 1574081000000,
+        "comments": [
+          {
+            "id": 21,
+            "text": "Inline reply",
+            "author": {"name": "user1", "slug": "user1"},
+            "createdDate": 1574081500000
+          }
+        ]
+      },
+      "commentAnchor": {"line": 2, "lineType": "ADDED", "fileType": "TO", "path": "README.md"},
+      "diff": {
+        "destination": {"toString": "README.md"},
+        "hunks": [
+          {
+            "sourceLine": 1, "sourceSpan": 2, "destinationLine": 1, "destinationSpan": 3,
+            "segments": [
+              {"type": "CONTEXT", "lines": [{"source": 1, "destination": 1, "line": "# README"}]},
+              {"type": "ADDED", "lines": [{"source": 2, "destination": 2, "line": "added"}, {"source": 2, "destination": 3, "line": "more"}]}
+            ]
+          }
+        ]
+      }
+    },
+    {
+      "id": 2,
+      "createdDate": 1574080000000,
+      "user": {"name": "user1", "slug": "user1"},
+      "action": "UPDATED",
+      "addedReviewers": [{"name": "user2", "slug": "user2"}]
+    },
+    {
+      "id": 1,
+      "createdDate": 1574079000000,
+      "user": {"name": "user1", "slug": "user1"},
+      "action": "COMMENTED",
+      "commentAction": "ADDED",
+      "comment": {
+        "id": 10,
+        "text": "General comment",
+        "author": {"name": "user1", "slug": "user1"},
+        "createdDate": 1574079000000,
+        "comments": [
+          {
+            "id": 11,
+            "text": "Reply",
+            "author": {"name": "user2", "slug": "user2"},
+            "createdDate": 1574083000000
+          }
+        ]
+      }
+    }
+  ]
+}`
+
+func newTestClient(t *testing.T) github.Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/1.0/projects/PRJ/repos/repo/pull-requests/1/activities", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Write([]byte(testActivities))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return New("token", srv.URL, "")
+}
+
+func TestClientListComments(t *testing.T) {
+	cli := newTestClient(t)
+	got, err := github.CommentsToSlice(cli.ListComments("PRJ/repo", 1))
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "General comment", got[0].Body)
+	assert.Equal(t, "user1", got[0].User.Login)
+	assert.Equal(t, "2019-11-18T12:10:00Z", got[0].CreatedAt)
+	assert.Equal(t, "Reply", got[1].Body)
+	assert.Equal(t, "user2", got[1].User.Login)
+}
+
+func TestClientListEvents(t *testing.T) {
+	cli := newTestClient(t)
+	got, err := github.EventsToSlice(cli.ListEvents("PRJ/repo", 1))
+	assert.Nil(t, err)
+	var events []string
+	for _, e := range got {
+		events = append(events, e.Event)
+	}
+	assert.Equal(t, []string{"review_requested", "merged", "closed"}, events)
+	assert.Equal(t, "user2", got[0].Reviewer.Login)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", got[1].CommitID)
+}
+
+func TestClientListReviews(t *testing.T) {
+	cli := newTestClient(t)
+	got, err := github.ReviewsToSlice(cli.ListReviews("PRJ/repo", 1))
+	assert.Nil(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, github.ReviewStateApproved, got[0].State)
+	assert.Equal(t, "user2", got[0].User.Login)
+}
+
+func TestClientListReviewComments(t *testing.T) {
+	cli := newTestClient(t)
+	got, err := github.ReviewCommentsToSlice(cli.ListReviewComments("PRJ/repo", 1))
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "README.md", got[0].Path)
+	assert.Equal(t, "@@ -1,2 +1,3 @@\n # README\n+added", got[0].DiffHunk)
+	assert.Equal(t, 0, got[0].InReplyToID)
+	assert.Equal(t, "Inline reply", got[1].Body)
+	assert.Equal(t, 20, got[1].InReplyToID)
+}
@@ -0,0 +1,193 @@
+package bitbucket
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// New creates a new Bitbucket Server client.
+// The client implements github.Client so that the pull requests in Bitbucket
+// Server can be migrated as imported issues. Repositories are specified by
+// PROJECT/repository, for example PRJ/my-repo.
+func New(token, endpoint, proxy string, opts ...ClientOption) github.Client {
+	cli := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+		},
+	}}
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			panic(err)
+		}
+		cli.Transport.(*http.Transport).Proxy = http.ProxyURL(proxyURL)
+	}
+	c := &client{
+		token:      token,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		client:     cli,
+		logger:     github.NewLogger(),
+		activities: make(map[string][]*activity),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ClientOption is an option of client.
+type ClientOption func(*client)
+
+// ClientLogger returns a client option to set the logger.
+func ClientLogger(l *github.Logger) ClientOption {
+	return func(c *client) {
+		c.logger = l
+	}
+}
+
+type client struct {
+	token, endpoint string
+	client          *http.Client
+	logger          *github.Logger
+
+	mu         sync.Mutex
+	activities map[string][]*activity
+}
+
+func (c *client) url(path string) string {
+	return c.endpoint + path
+}
+
+func repoPath(repo string) (string, error) {
+	xs := strings.Split(repo, "/")
+	if len(xs) != 2 || xs[0] == "" || xs[1] == "" {
+		return "", fmt.Errorf("invalid repository (expected PROJECT/repository): %s", repo)
+	}
+	return fmt.Sprintf("/rest/api/1.0/projects/%s/repos/%s", xs[0], xs[1]), nil
+}
+
+// repoURL returns the url of the repository (the path is already validated).
+func (c *client) repoURL(repo string) string {
+	xs := strings.Split(repo, "/")
+	return c.url(fmt.Sprintf("/projects/%s/repos/%s", xs[0], xs[1]))
+}
+
+func (c *client) do(method, path string) (*http.Response, error) {
+	var retryCnt int
+	duration := 10 * time.Second
+	for {
+		res, retry, err := c.doOnce(method, path)
+		if err == nil || !retry || retryCnt >= 5 {
+			return res, err
+		}
+		retryCnt++
+		time.Sleep(duration)
+		duration *= 2
+	}
+}
+
+func (c *client) doOnce(method, path string) (*http.Response, bool, error) {
+	req, err := http.NewRequest(method, path, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Add("Authorization", "Bearer "+c.token)
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("User-Agent", "github-migrator")
+	c.logger.PreRequest(req)
+	res, err := c.client.Do(req)
+	c.logger.PostRequest(res, err)
+	if err != nil {
+		return nil, true, err
+	}
+	if res.StatusCode < 200 || 400 <= res.StatusCode {
+		return nil, 500 <= res.StatusCode, getError(res)
+	}
+	return res, false, nil
+}
+
+func getError(res *http.Response) error {
+	defer res.Body.Close()
+	var r struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&r); err != nil || len(r.Errors) == 0 {
+		return errors.New(res.Status)
+	}
+	xs := make([]string, len(r.Errors))
+	for i, e := range r.Errors {
+		xs[i] = e.Message
+	}
+	return errors.New(strings.Join(xs, ", "))
+}
+
+func (c *client) get(path string, v interface{}) error {
+	res, err := c.do("GET", path)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// Download downloads the file (an attachment of a pull request) with the credentials.
+func (c *client) Download(url string) ([]byte, error) {
+	res, err := c.do("GET", url)
+	if err != nil {
+		return nil, fmt.Errorf("Download %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	return io.ReadAll(res.Body)
+}
+
+type page struct {
+	Values        json.RawMessage `json:"values"`
+	IsLastPage    bool            `json:"isLastPage"`
+	NextPageStart int             `json:"nextPageStart"`
+}
+
+// getPaged collects the values of the paged api.
+func (c *client) getPaged(path string, f func(json.RawMessage) error) error {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	var start int
+	for {
+		var p page
+		if err := c.get(fmt.Sprintf("%s%sstart=%d&limit=100", path, sep, start), &p); err != nil {
+			return err
+		}
+		if err := f(p.Values); err != nil {
+			return err
+		}
+		if p.IsLastPage {
+			return nil
+		}
+		start = p.NextPageStart
+	}
+}
+
+// Bitbucket Server returns milliseconds from the epoch.
+func formatTime(t int64) string {
+	if t == 0 {
+		return ""
+	}
+	return time.Unix(t/1000, t%1000*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+}
+
+func unsupported(name string) error {
+	return fmt.Errorf("%s: not supported by Bitbucket Server", name)
+}
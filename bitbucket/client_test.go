@@ -0,0 +1,11 @@
+package bitbucket
+
+import (
+	"testing"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestNew(t *testing.T) {
+	var _ github.Client = New("token", "http://localhost", "")
+}
@@ -0,0 +1,86 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+type commit struct {
+	ID                 string `json:"id"`
+	Author             *user  `json:"author"`
+	AuthorTimestamp    int64  `json:"authorTimestamp"`
+	Committer          *user  `json:"committer"`
+	CommitterTimestamp int64  `json:"committerTimestamp"`
+	Message            string `json:"message"`
+	Parents            []struct {
+		ID string `json:"id"`
+	} `json:"parents"`
+}
+
+func (c *client) toCommit(repoURL string, x *commit) *github.Commit {
+	y := &github.Commit{
+		SHA:     x.ID,
+		HTMLURL: repoURL + "/commits/" + x.ID,
+	}
+	y.Commit.Message = x.Message
+	if x.Author != nil {
+		y.Commit.Author = &github.CommitUser{
+			Name:  x.Author.Name,
+			Email: x.Author.EmailAddress,
+			Date:  formatTime(x.AuthorTimestamp),
+		}
+		if x.Author.Slug != "" {
+			y.Author = c.toUser(x.Author)
+		}
+	}
+	if x.Committer != nil {
+		y.Commit.Committer = &github.CommitUser{
+			Name:  x.Committer.Name,
+			Email: x.Committer.EmailAddress,
+			Date:  formatTime(x.CommitterTimestamp),
+		}
+		if x.Committer.Slug != "" {
+			y.Committer = c.toUser(x.Committer)
+		}
+	} else {
+		y.Commit.Committer = y.Commit.Author
+	}
+	for _, p := range x.Parents {
+		y.Parents = append(y.Parents, struct {
+			URL string `json:"url"`
+			SHA string `json:"sha"`
+		}{SHA: p.ID})
+	}
+	return y
+}
+
+// ListPullReqCommits lists the commits of a pull request, the oldest first.
+func (c *client) ListPullReqCommits(repo string, pullNumber int) github.Commits {
+	cs := make(chan interface{})
+	go func() {
+		defer close(cs)
+		path, err := repoPath(repo)
+		if err != nil {
+			cs <- err
+			return
+		}
+		var xs []*commit
+		if err := c.getPaged(c.url(fmt.Sprintf("%s/pull-requests/%d/commits", path, pullNumber)), func(bs json.RawMessage) error {
+			var ys []*commit
+			if err := json.Unmarshal(bs, &ys); err != nil {
+				return err
+			}
+			xs = append(xs, ys...)
+			return nil
+		}); err != nil {
+			cs <- fmt.Errorf("ListPullReqCommits %s/pull-requests/%d: %w", repo, pullNumber, err)
+			return
+		}
+		for i := len(xs) - 1; i >= 0; i-- {
+			cs <- c.toCommit(c.repoURL(repo), xs[i])
+		}
+	}()
+	return github.Commits(cs)
+}
@@ -0,0 +1,165 @@
+package bitbucket
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type diff struct {
+	Source      *diffPath   `json:"source"`
+	Destination *diffPath   `json:"destination"`
+	Hunks       []*diffHunk `json:"hunks"`
+	Binary      bool        `json:"binary"`
+}
+
+type diffPath struct {
+	ToString string `json:"toString"`
+}
+
+type diffHunk struct {
+	SourceLine      int            `json:"sourceLine"`
+	SourceSpan      int            `json:"sourceSpan"`
+	DestinationLine int            `json:"destinationLine"`
+	DestinationSpan int            `json:"destinationSpan"`
+	Segments        []*diffSegment `json:"segments"`
+}
+
+type diffSegment struct {
+	Type  string `json:"type"`
+	Lines []struct {
+		Source      int    `json:"source"`
+		Destination int    `json:"destination"`
+		Line        string `json:"line"`
+	} `json:"lines"`
+}
+
+var segmentPrefixes = map[string]string{
+	"ADDED":   "+",
+	"REMOVED": "-",
+	"CONTEXT": " ",
+}
+
+func (h *diffHunk) header() string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.SourceLine, h.SourceSpan, h.DestinationLine, h.DestinationSpan)
+}
+
+func (d *diff) path() string {
+	if d.Destination != nil {
+		return d.Destination.ToString
+	}
+	if d.Source != nil {
+		return d.Source.ToString
+	}
+	return ""
+}
+
+func (d *diff) stat() (additions, deletions int) {
+	for _, h := range d.Hunks {
+		for _, s := range h.Segments {
+			switch s.Type {
+			case "ADDED":
+				additions += len(s.Lines)
+			case "REMOVED":
+				deletions += len(s.Lines)
+			}
+		}
+	}
+	return
+}
+
+// render the diff in the unified format like git diff.
+func (d *diff) render(s *strings.Builder, base, head string) {
+	source, destination := "/dev/null", "/dev/null"
+	if d.Source != nil {
+		source = "a/" + d.Source.ToString
+	}
+	if d.Destination != nil {
+		destination = "b/" + d.Destination.ToString
+	}
+	fmt.Fprintf(s, "diff --git a/%s b/%s\n", d.path(), d.path())
+	fmt.Fprintf(s, "index %.7s..%.7s\n", base, head)
+	if d.Binary {
+		fmt.Fprintf(s, "Binary files %s and %s differ\n", source, destination)
+		return
+	}
+	fmt.Fprintf(s, "--- %s\n+++ %s\n", source, destination)
+	for _, h := range d.Hunks {
+		s.WriteString(h.header() + "\n")
+		for _, g := range h.Segments {
+			for _, l := range g.Lines {
+				s.WriteString(segmentPrefixes[g.Type] + l.Line + "\n")
+			}
+		}
+	}
+}
+
+// buildDiffHunk builds the hunk ending at the commented line, like the
+// diff_hunk of GitHub review comments.
+func buildDiffHunk(d *diff, anchor *commentAnchor) string {
+	var lines []string
+	for _, h := range d.Hunks {
+		lines = append(lines, h.header())
+		for _, g := range h.Segments {
+			for _, l := range g.Lines {
+				lines = append(lines, segmentPrefixes[g.Type]+l.Line)
+				line := l.Destination
+				if anchor.FileType == "FROM" {
+					line = l.Source
+				}
+				if g.Type == anchor.LineType && line == anchor.Line {
+					return strings.Join(lines, "\n")
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (c *client) getCompareDiffs(repo, base, head string) ([]*diff, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	var r struct {
+		Diffs []*diff `json:"diffs"`
+	}
+	if err := c.get(c.url(fmt.Sprintf(
+		"%s/compare/diff?from=%s&to=%s&contextLines=3",
+		path, url.QueryEscape(head), url.QueryEscape(base),
+	)), &r); err != nil {
+		return nil, err
+	}
+	return r.Diffs, nil
+}
+
+func (c *client) GetDiff(repo string, sha string) (string, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return "", err
+	}
+	var r struct {
+		Diffs []*diff `json:"diffs"`
+	}
+	if err := c.get(c.url(fmt.Sprintf("%s/commits/%s/diff?contextLines=3", path, sha)), &r); err != nil {
+		return "", fmt.Errorf("GetDiff %s: %w", fmt.Sprintf("%s/commits/%s", repo, sha), err)
+	}
+	s := new(strings.Builder)
+	for _, d := range r.Diffs {
+		d.render(s, sha+"^", sha)
+	}
+	return s.String(), nil
+}
+
+// GetCompare builds the diff of the changes in head but not in base.
+func (c *client) GetCompare(repo string, base, head string) (string, error) {
+	diffs, err := c.getCompareDiffs(repo, base, head)
+	if err != nil {
+		return "", fmt.Errorf("GetCompare %s: %w", fmt.Sprintf("%s/compare/%s...%s", repo, base, head), err)
+	}
+	s := new(strings.Builder)
+	for _, d := range diffs {
+		d.render(s, base, head)
+	}
+	return s.String(), nil
+}
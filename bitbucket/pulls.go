@@ -0,0 +1,211 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+type pullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Open        bool   `json:"open"`
+	CreatedDate int64  `json:"createdDate"`
+	UpdatedDate int64  `json:"updatedDate"`
+	ClosedDate  int64  `json:"closedDate"`
+	FromRef     *ref   `json:"fromRef"`
+	ToRef       *ref   `json:"toRef"`
+	Author      struct {
+		User *user `json:"user"`
+	} `json:"author"`
+	Links links `json:"links"`
+}
+
+type ref struct {
+	ID           string      `json:"id"`
+	DisplayID    string      `json:"displayId"`
+	LatestCommit string      `json:"latestCommit"`
+	Repository   *repository `json:"repository"`
+}
+
+func (c *client) toIssue(p *pullRequest) *github.Issue {
+	state := github.IssueStateClosed
+	if p.Open {
+		state = github.IssueStateOpen
+	}
+	return &github.Issue{
+		ID:        p.ID,
+		Number:    p.ID,
+		Title:     p.Title,
+		State:     state,
+		Body:      p.Description,
+		HTMLURL:   p.Links.href(),
+		User:      c.toUser(p.Author.User),
+		CreatedAt: formatTime(p.CreatedDate),
+		UpdatedAt: formatTime(p.UpdatedDate),
+		ClosedAt:  formatTime(p.ClosedDate),
+		Labels:    []*github.Label{},
+		PullRequest: &github.IssuePullRequest{
+			HTMLURL: p.Links.href(),
+		},
+	}
+}
+
+func (c *client) toPullReq(p *pullRequest) *github.PullReq {
+	pullReq := &github.PullReq{
+		Issue:  *c.toIssue(p),
+		Merged: p.State == "MERGED",
+		Head:   toPullReqRef(p.FromRef),
+		Base:   toPullReqRef(p.ToRef),
+	}
+	if pullReq.Merged {
+		pullReq.MergedAt = formatTime(p.ClosedDate)
+	}
+	return pullReq
+}
+
+func toPullReqRef(r *ref) *github.PullReqRef {
+	if r == nil {
+		return nil
+	}
+	x := &github.PullReqRef{SHA: r.LatestCommit, Ref: r.DisplayID}
+	if r.Repository != nil {
+		x.Repo = &github.Repo{
+			Name:     r.Repository.Slug,
+			FullName: r.Repository.Project.Key + "/" + r.Repository.Slug,
+		}
+	}
+	return x
+}
+
+func (c *client) listPullRequests(repo string) ([]*pullRequest, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	xs := []*pullRequest{}
+	if err := c.getPaged(c.url(path+"/pull-requests?state=ALL&order=OLDEST"), func(bs json.RawMessage) error {
+		var ys []*pullRequest
+		if err := json.Unmarshal(bs, &ys); err != nil {
+			return err
+		}
+		xs = append(xs, ys...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].ID < xs[j].ID
+	})
+	return xs, nil
+}
+
+func (c *client) getPullRequest(repo string, pullNumber int) (*pullRequest, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	var r pullRequest
+	if err := c.get(c.url(fmt.Sprintf("%s/pull-requests/%d", path, pullNumber)), &r); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// ListIssues lists the pull requests as issues since Bitbucket Server has no issues.
+func (c *client) ListIssues(repo string, _ *github.ListIssuesParams) github.Issues {
+	is := make(chan interface{})
+	go func() {
+		defer close(is)
+		xs, err := c.listPullRequests(repo)
+		if err != nil {
+			is <- fmt.Errorf("ListIssues %s: %w", repo, err)
+			return
+		}
+		for _, x := range xs {
+			is <- c.toIssue(x)
+		}
+	}()
+	return github.Issues(is)
+}
+
+func (c *client) GetIssue(repo string, issueNumber int) (*github.Issue, error) {
+	p, err := c.getPullRequest(repo, issueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("GetIssue %s: %w", fmt.Sprintf("%s/pull-requests/%d", repo, issueNumber), err)
+	}
+	return c.toIssue(p), nil
+}
+
+func (c *client) ListAssignees(string) github.Users {
+	us := make(chan interface{}, 1)
+	us <- unsupported("ListAssignees")
+	close(us)
+	return github.Users(us)
+}
+
+func (c *client) AddAssignees(string, int, []string) error {
+	return unsupported("AddAssignees")
+}
+
+// ListPullReqs lists the pull requests.
+func (c *client) ListPullReqs(repo string, _ *github.ListPullReqsParams) github.PullReqs {
+	ps := make(chan interface{})
+	go func() {
+		defer close(ps)
+		xs, err := c.listPullRequests(repo)
+		if err != nil {
+			ps <- fmt.Errorf("ListPullReqs %s: %w", repo, err)
+			return
+		}
+		for _, x := range xs {
+			ps <- c.toPullReq(x)
+		}
+	}()
+	return github.PullReqs(ps)
+}
+
+// GetPullReq gets the pull request. The merge information is looked up from
+// the activities, and the numbers of commits and changes are counted since
+// Bitbucket Server does not provide them in the pull request.
+func (c *client) GetPullReq(repo string, pullNumber int) (*github.PullReq, error) {
+	p, err := c.getPullRequest(repo, pullNumber)
+	if err != nil {
+		return nil, fmt.Errorf("GetPullReq %s: %w", fmt.Sprintf("%s/pull-requests/%d", repo, pullNumber), err)
+	}
+	pullReq := c.toPullReq(p)
+	as, err := c.getActivities(repo, pullNumber)
+	if err != nil {
+		return nil, fmt.Errorf("GetPullReq %s: %w", fmt.Sprintf("%s/pull-requests/%d", repo, pullNumber), err)
+	}
+	for _, a := range as {
+		if a.Action == "MERGED" {
+			pullReq.MergedBy = c.toUser(a.User)
+			if a.Commit != nil {
+				pullReq.MergeCommitSHA = a.Commit.ID
+			}
+		}
+	}
+	commits, err := github.CommitsToSlice(c.ListPullReqCommits(repo, pullNumber))
+	if err != nil {
+		return nil, err
+	}
+	pullReq.Commits = len(commits)
+	if pullReq.Base != nil && pullReq.Head != nil && pullReq.Base.Repo != nil {
+		diffs, err := c.getCompareDiffs(pullReq.Base.Repo.FullName, pullReq.Base.SHA, pullReq.Head.SHA)
+		if err != nil {
+			return nil, fmt.Errorf("GetPullReq %s: %w", fmt.Sprintf("%s/pull-requests/%d", repo, pullNumber), err)
+		}
+		pullReq.ChangedFiles = len(diffs)
+		for _, d := range diffs {
+			additions, deletions := d.stat()
+			pullReq.Additions += additions
+			pullReq.Deletions += deletions
+		}
+	}
+	return pullReq, nil
+}
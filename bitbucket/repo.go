@@ -0,0 +1,54 @@
+package bitbucket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+type repository struct {
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+	Project     struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Links links `json:"links"`
+}
+
+type links struct {
+	Self []struct {
+		Href string `json:"href"`
+	} `json:"self"`
+}
+
+func (l links) href() string {
+	if len(l.Self) == 0 {
+		return ""
+	}
+	return l.Self[0].Href
+}
+
+func (c *client) GetRepo(repo string) (*github.Repo, error) {
+	path, err := repoPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	var r repository
+	if err := c.get(c.url(path), &r); err != nil {
+		return nil, fmt.Errorf("GetRepo %s: %w", repo, err)
+	}
+	return &github.Repo{
+		Name:        r.Slug,
+		FullName:    r.Project.Key + "/" + r.Slug,
+		Description: r.Description,
+		HTMLURL:     strings.TrimSuffix(r.Links.href(), "/browse"),
+		Private:     !r.Public,
+	}, nil
+}
+
+func (c *client) UpdateRepo(string, *github.UpdateRepoParams) (*github.Repo, error) {
+	return nil, unsupported("UpdateRepo")
+}
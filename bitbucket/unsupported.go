@@ -0,0 +1,155 @@
+package bitbucket
+
+import "github.com/itchyny/github-migrator/github"
+
+// Bitbucket Server has no labels, projects, milestones and issue imports.
+// The listing methods return nothing so that the migrator skips them,
+// and the other methods return errors since the client is only for sources.
+
+func (c *client) ListLabels(string) github.Labels {
+	return github.LabelsFromSlice([]*github.Label{})
+}
+
+func (c *client) ListIssueReactions(string, int) github.Reactions {
+	return github.ReactionsFromSlice([]*github.Reaction{})
+}
+
+func (c *client) ListCommentReactions(string, int) github.Reactions {
+	return github.ReactionsFromSlice([]*github.Reaction{})
+}
+
+func (c *client) ListReviewCommentReactions(string, int) github.Reactions {
+	return github.ReactionsFromSlice([]*github.Reaction{})
+}
+
+func (c *client) CreateIssue(string, *github.CreateIssueParams) (*github.Issue, error) {
+	return nil, unsupported("CreateIssue")
+}
+
+func (c *client) UpdateIssue(string, int, *github.UpdateIssueParams) (*github.Issue, error) {
+	return nil, unsupported("UpdateIssue")
+}
+
+func (c *client) CreateComment(string, int, string) (*github.Comment, error) {
+	return nil, unsupported("CreateComment")
+}
+
+func (c *client) CreateLabel(string, *github.CreateLabelParams) (*github.Label, error) {
+	return nil, unsupported("CreateLabel")
+}
+
+func (c *client) UpdateLabel(string, string, *github.UpdateLabelParams) (*github.Label, error) {
+	return nil, unsupported("UpdateLabel")
+}
+
+func (c *client) DeleteLabel(string, string) error {
+	return unsupported("DeleteLabel")
+}
+
+func (c *client) ListProjects(string, *github.ListProjectsParams) github.Projects {
+	return github.ProjectsFromSlice([]*github.Project{})
+}
+
+func (c *client) GetProject(int) (*github.Project, error) {
+	return nil, unsupported("GetProject")
+}
+
+func (c *client) CreateProject(string, *github.CreateProjectParams) (*github.Project, error) {
+	return nil, unsupported("CreateProject")
+}
+
+func (c *client) UpdateProject(int, *github.UpdateProjectParams) (*github.Project, error) {
+	return nil, unsupported("UpdateProject")
+}
+
+func (c *client) DeleteProject(int) error {
+	return unsupported("DeleteProject")
+}
+
+func (c *client) ListProjectColumns(int) github.ProjectColumns {
+	return github.ProjectColumnsFromSlice([]*github.ProjectColumn{})
+}
+
+func (c *client) GetProjectColumn(int) (*github.ProjectColumn, error) {
+	return nil, unsupported("GetProjectColumn")
+}
+
+func (c *client) CreateProjectColumn(int, string) (*github.ProjectColumn, error) {
+	return nil, unsupported("CreateProjectColumn")
+}
+
+func (c *client) UpdateProjectColumn(int, string) (*github.ProjectColumn, error) {
+	return nil, unsupported("UpdateProjectColumn")
+}
+
+func (c *client) ListProjectCards(int) github.ProjectCards {
+	return github.ProjectCardsFromSlice([]*github.ProjectCard{})
+}
+
+func (c *client) GetProjectCard(int) (*github.ProjectCard, error) {
+	return nil, unsupported("GetProjectCard")
+}
+
+func (c *client) CreateProjectCard(int, *github.CreateProjectCardParams) (*github.ProjectCard, error) {
+	return nil, unsupported("CreateProjectCard")
+}
+
+func (c *client) UpdateProjectCard(int, *github.UpdateProjectCardParams) (*github.ProjectCard, error) {
+	return nil, unsupported("UpdateProjectCard")
+}
+
+func (c *client) MoveProjectCard(int, *github.MoveProjectCardParams) (*github.ProjectCard, error) {
+	return nil, unsupported("MoveProjectCard")
+}
+
+func (c *client) ListMilestones(string, *github.ListMilestonesParams) github.Milestones {
+	return github.MilestonesFromSlice([]*github.Milestone{})
+}
+
+func (c *client) GetMilestone(string, int) (*github.Milestone, error) {
+	return nil, unsupported("GetMilestone")
+}
+
+func (c *client) CreateMilestone(string, *github.CreateMilestoneParams) (*github.Milestone, error) {
+	return nil, unsupported("CreateMilestone")
+}
+
+func (c *client) UpdateMilestone(string, int, *github.UpdateMilestoneParams) (*github.Milestone, error) {
+	return nil, unsupported("UpdateMilestone")
+}
+
+func (c *client) DeleteMilestone(string, int) error {
+	return unsupported("DeleteMilestone")
+}
+
+func (c *client) ListHooks(string) github.Hooks {
+	return github.HooksFromSlice([]*github.Hook{})
+}
+
+func (c *client) GetHook(string, int) (*github.Hook, error) {
+	return nil, unsupported("GetHook")
+}
+
+func (c *client) CreateHook(string, *github.CreateHookParams) (*github.Hook, error) {
+	return nil, unsupported("CreateHook")
+}
+
+func (c *client) UpdateHook(string, int, *github.UpdateHookParams) (*github.Hook, error) {
+	return nil, unsupported("UpdateHook")
+}
+
+func (c *client) Import(string, *github.Import) (*github.ImportResult, error) {
+	return nil, unsupported("Import")
+}
+
+func (c *client) GetImport(string, int) (*github.ImportResult, error) {
+	return nil, unsupported("GetImport")
+}
+
+func (c *client) RenderMarkdown(string, string) (string, error) {
+	return "", unsupported("RenderMarkdown")
+}
+
+func (c *client) CreateFile(string, string, *github.CreateFileParams) (*github.File, error) {
+	return nil, unsupported("CreateFile")
+}
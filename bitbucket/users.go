@@ -0,0 +1,94 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+type user struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	ID           int    `json:"id"`
+	DisplayName  string `json:"displayName"`
+	Slug         string `json:"slug"`
+}
+
+func (c *client) toUser(u *user) *github.User {
+	if u == nil {
+		return nil
+	}
+	login := u.Slug
+	if login == "" {
+		login = u.Name
+	}
+	return &github.User{
+		Login:   login,
+		HTMLURL: c.url("/users/" + url.PathEscape(login)),
+		Name:    u.DisplayName,
+		Email:   u.EmailAddress,
+	}
+}
+
+// GetLogin gets the authenticated user.
+// Bitbucket Server tells the user name in the X-AUSERNAME header.
+func (c *client) GetLogin() (*github.User, error) {
+	res, err := c.do("GET", c.url("/rest/api/1.0/application-properties"))
+	if err != nil {
+		return nil, fmt.Errorf("GetLogin %s: %w", "/application-properties", err)
+	}
+	defer res.Body.Close()
+	name := res.Header.Get("X-AUSERNAME")
+	if name == "" {
+		return nil, errors.New("GetLogin: authentication failed")
+	}
+	return c.GetUser(name)
+}
+
+// ListUsers lists all the users.
+func (c *client) ListUsers() github.Users {
+	us := make(chan interface{})
+	go func() {
+		defer close(us)
+		if err := c.getPaged(c.url("/rest/api/1.0/users"), func(bs json.RawMessage) error {
+			var xs []*user
+			if err := json.Unmarshal(bs, &xs); err != nil {
+				return err
+			}
+			for _, x := range xs {
+				us <- c.toUser(x)
+			}
+			return nil
+		}); err != nil {
+			us <- fmt.Errorf("ListUsers /users: %w", err)
+		}
+	}()
+	return github.Users(us)
+}
+
+// GetUser gets the user.
+func (c *client) GetUser(name string) (*github.User, error) {
+	var r user
+	if err := c.get(c.url("/rest/api/1.0/users/"+url.PathEscape(name)), &r); err != nil {
+		return nil, fmt.Errorf("GetUser %s: %w", fmt.Sprintf("/users/%s", name), err)
+	}
+	return c.toUser(&r), nil
+}
+
+// ListMembers returns no members since Bitbucket Server has no organizations.
+func (c *client) ListMembers(string) github.Members {
+	return github.MembersFromSlice([]*github.Member{})
+}
+
+// ListTeams returns no teams since Bitbucket Server has no organizations.
+func (c *client) ListTeams(string) github.Teams {
+	return github.TeamsFromSlice([]*github.Team{})
+}
+
+// GetTeam is not supported since Bitbucket Server has no organizations.
+func (c *client) GetTeam(string, string) (*github.Team, error) {
+	return nil, unsupported("GetTeam")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/itchyny/github-migrator/migrator"
+)
+
+// config is loaded from the YAML file specified by GITHUB_MIGRATOR_CONFIG,
+// for the settings which are too complex for the environment variables.
+type config struct {
+	RewriteRules     migrator.RewriteRules      `yaml:"rewrite_rules"`
+	Redaction        *redactionConfig           `yaml:"redaction"`
+	TeamMapping      *teamMappingConfig         `yaml:"team_mapping"`
+	LabelMapping     migrator.LabelMapping      `yaml:"label_mapping"`
+	MilestoneMapping map[string]string          `yaml:"milestone_mapping"`
+	Conflicts        *migrator.ConflictPolicies `yaml:"conflict_policies"`
+	Prune            *migrator.Prune            `yaml:"prune"`
+	Renumbering      *renumberingConfig         `yaml:"renumbering"`
+	Merge            *mergeConfig               `yaml:"merge"`
+	RoutingRules     migrator.RoutingRules      `yaml:"routing_rules"`
+	RepoMapping      migrator.RepoMapping       `yaml:"repo_mapping"`
+	HostLinks        *hostLinksConfig           `yaml:"host_links"`
+	ValidateUsers    *validateUsersConfig       `yaml:"validate_users"`
+}
+
+type validateUsersConfig struct {
+	AllowUnmapped bool `yaml:"allow_unmapped"`
+}
+
+type hostLinksConfig struct {
+	Report string `yaml:"report"`
+}
+
+type mergeConfig struct {
+	Order       migrator.MergeOrder `yaml:"order"`
+	LabelPrefix *string             `yaml:"label_prefix"`
+}
+
+type renumberingConfig struct {
+	Offset  int    `yaml:"offset"`
+	MapFile string `yaml:"map_file"`
+}
+
+type teamMappingConfig struct {
+	Teams  map[string]string `yaml:"teams"`
+	Derive bool              `yaml:"derive"`
+}
+
+type redactionConfig struct {
+	Patterns migrator.RedactionPatterns `yaml:"patterns"`
+	Report   string                     `yaml:"report"`
+}
+
+func loadConfig() (*config, error) {
+	path := os.Getenv("GITHUB_MIGRATOR_CONFIG")
+	if path == "" {
+		return &config{}, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var c config
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := c.RewriteRules.Compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(c.RoutingRules) > 0 {
+		if err := c.RoutingRules.Compile(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := c.LabelMapping.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := c.RepoMapping.Load(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if c.Renumbering != nil {
+		if c.Renumbering.Offset < 0 {
+			return nil, fmt.Errorf("%s: renumbering offset should not be negative: %d", path, c.Renumbering.Offset)
+		}
+		if c.Renumbering.MapFile == "" {
+			c.Renumbering.MapFile = "issue-numbers.json"
+		}
+	}
+	if c.HostLinks != nil && c.HostLinks.Report == "" {
+		c.HostLinks.Report = "unmapped-links.json"
+	}
+	if c.Redaction != nil {
+		if err := c.Redaction.Patterns.Compile(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if c.Redaction.Report == "" {
+			c.Redaction.Report = "redactions.json"
+		}
+	}
+	return &c, nil
+}
+
+// testRules applies the rewrite rules to the sample text (read from the file
+// or stdin), and shows the matches of each rule and the rewritten text.
+func testRules(args []string) error {
+	c, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(c.RewriteRules) == 0 {
+		return errors.New("no rewrite rules in the config (specify GITHUB_MIGRATOR_CONFIG)")
+	}
+	var bs []byte
+	if len(args) > 0 {
+		bs, err = os.ReadFile(args[0])
+	} else {
+		bs, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	src := string(bs)
+	dst := src
+	for i, r := range c.RewriteRules {
+		fmt.Printf("[<>] rule %d: %s (matches: %d)\n", i+1, r, r.Count(dst))
+		dst = r.Apply(dst)
+	}
+	fmt.Printf("--- before\n%s", src)
+	if !strings.HasSuffix(src, "\n") {
+		fmt.Println()
+	}
+	fmt.Printf("+++ after\n%s", dst)
+	if !strings.HasSuffix(dst, "\n") {
+		fmt.Println()
+	}
+	return nil
+}
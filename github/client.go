@@ -20,21 +20,32 @@ type Client interface {
 	ListUsers() Users
 	GetUser(string) (*User, error)
 	ListMembers(string) Members
+	ListTeams(string) Teams
+	GetTeam(string, string) (*Team, error)
 	GetRepo(string) (*Repo, error)
 	UpdateRepo(string, *UpdateRepoParams) (*Repo, error)
 	ListLabels(string) Labels
 	CreateLabel(string, *CreateLabelParams) (*Label, error)
 	UpdateLabel(string, string, *UpdateLabelParams) (*Label, error)
+	DeleteLabel(string, string) error
 	ListIssues(string, *ListIssuesParams) Issues
 	GetIssue(string, int) (*Issue, error)
+	CreateIssue(string, *CreateIssueParams) (*Issue, error)
+	UpdateIssue(string, int, *UpdateIssueParams) (*Issue, error)
+	ListAssignees(string) Users
 	AddAssignees(string, int, []string) error
 	ListComments(string, int) Comments
+	CreateComment(string, int, string) (*Comment, error)
 	ListEvents(string, int) Events
+	ListIssueReactions(string, int) Reactions
+	ListCommentReactions(string, int) Reactions
+	ListReviewCommentReactions(string, int) Reactions
 	ListPullReqs(string, *ListPullReqsParams) PullReqs
 	GetPullReq(string, int) (*PullReq, error)
 	ListPullReqCommits(string, int) Commits
 	GetDiff(string, string) (string, error)
 	GetCompare(string, string, string) (string, error)
+	RenderMarkdown(string, string) (string, error)
 	ListReviews(string, int) Reviews
 	GetReview(string, int, int) (*Review, error)
 	ListReviewComments(string, int) ReviewComments
@@ -63,6 +74,8 @@ type Client interface {
 	UpdateHook(string, int, *UpdateHookParams) (*Hook, error)
 	Import(string, *Import) (*ImportResult, error)
 	GetImport(string, int) (*ImportResult, error)
+	CreateFile(string, string, *CreateFileParams) (*File, error)
+	Download(string) ([]byte, error)
 }
 
 // New creates a new GitHub client.
@@ -153,14 +166,15 @@ func (c *client) request(method, path string, body io.Reader) (*http.Request, er
 	req.Header.Add("Accept", "application/vnd.github.sailor-v-preview+json")
 	req.Header.Add("Accept", "application/vnd.github.starfox-preview+json")
 	req.Header.Add("Accept", "application/vnd.github.inertia-preview+json")
+	req.Header.Add("Accept", "application/vnd.github.squirrel-girl-preview+json")
 	req.Header.Add("User-Agent", "github-migrator")
 	return req, nil
 }
 
 func (c *client) doReq(req *http.Request) (*http.Response, bool, error) {
-	c.logger.preRequest(req)
+	c.logger.PreRequest(req)
 	res, err := c.client.Do(req)
-	c.logger.postRequest(res, err)
+	c.logger.PostRequest(res, err)
 	if err != nil {
 		return nil, true, err
 	}
@@ -221,6 +235,18 @@ func (c *client) patch(path string, body, v interface{}) error {
 	return nil
 }
 
+func (c *client) put(path string, body, v interface{}) error {
+	res, err := c.do("PUT", path, body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if err := json.NewDecoder(res.Body).Decode(&v); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *client) delete(path string) error {
 	res, err := c.do("DELETE", path, nil)
 	if err != nil {
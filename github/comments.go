@@ -7,11 +7,13 @@ import (
 
 // Comment represents a comment.
 type Comment struct {
-	Body      string `json:"body"`
-	HTMLURL   string `json:"html_url"`
-	User      *User  `json:"user"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID        int              `json:"id"`
+	Body      string           `json:"body"`
+	HTMLURL   string           `json:"html_url"`
+	User      *User            `json:"user"`
+	CreatedAt string           `json:"created_at"`
+	UpdatedAt string           `json:"updated_at"`
+	Reactions *ReactionSummary `json:"reactions,omitempty"`
 }
 
 // Comments represents a collection of comments.
@@ -82,3 +84,13 @@ func (c *client) ListComments(repo string, issueNumber int) Comments {
 	}()
 	return Comments(cs)
 }
+
+// CreateComment creates a new comment on the issue.
+func (c *client) CreateComment(repo string, issueNumber int, body string) (*Comment, error) {
+	var r Comment
+	params := map[string]string{"body": body}
+	if err := c.post(c.url(fmt.Sprintf("/repos/%s/issues/%d/comments", repo, issueNumber)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateComment %s: %w", fmt.Sprintf("%s/issues/%d/comments", repo, issueNumber), err)
+	}
+	return &r, nil
+}
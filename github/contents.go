@@ -0,0 +1,65 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// File represents a file in a repository.
+type File struct {
+	Name        string `json:"name"`
+	Path        string `json:"path"`
+	SHA         string `json:"sha"`
+	HTMLURL     string `json:"html_url"`
+	DownloadURL string `json:"download_url"`
+}
+
+// CreateFileParams represents the parameter for CreateFile API.
+// The content is encoded in base64 on marshaling.
+type CreateFileParams struct {
+	Message string `json:"message"`
+	Content []byte `json:"content"`
+	Branch  string `json:"branch,omitempty"`
+}
+
+// CreateFile creates a file in the repository.
+func (c *client) CreateFile(repo, path string, params *CreateFileParams) (*File, error) {
+	var r struct {
+		Content *File `json:"content"`
+	}
+	if err := c.put(c.url(fmt.Sprintf("/repos/%s/contents/%s", repo, EscapePath(path))), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateFile %s: %w", fmt.Sprintf("%s/contents/%s", repo, path), err)
+	}
+	return r.Content, nil
+}
+
+// Download downloads the file (an attachment of an issue) with the credentials.
+func (c *client) Download(url string) ([]byte, error) {
+	req, err := c.request("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+	res, _, err := c.doReq(req)
+	if err != nil {
+		return nil, fmt.Errorf("Download %s: %w", url, err)
+	}
+	defer res.Body.Close()
+	if strings.HasPrefix(res.Header.Get("Content-Type"), "text/html") {
+		// the login page is returned when the credentials are not accepted
+		return nil, fmt.Errorf("Download %s: unexpected content type: %s", url, res.Header.Get("Content-Type"))
+	}
+	return io.ReadAll(res.Body)
+}
+
+// EscapePath escapes each segment of the path, for the contents API and the
+// raw file urls.
+func EscapePath(path string) string {
+	xs := strings.Split(path, "/")
+	for i, x := range xs {
+		xs[i] = url.PathEscape(x)
+	}
+	return strings.Join(xs, "/")
+}
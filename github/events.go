@@ -42,6 +42,7 @@ type EventRename struct {
 type EventTeam struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+	Slug string `json:"slug"`
 }
 
 // EventDismissedReview ...
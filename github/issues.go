@@ -25,6 +25,7 @@ type Issue struct {
 	Labels      []*Label          `json:"labels"`
 	PullRequest *IssuePullRequest `json:"pull_request"`
 	Milestone   *Milestone        `json:"milestone"`
+	Reactions   *ReactionSummary  `json:"reactions,omitempty"`
 }
 
 // IssueState ...
@@ -160,6 +161,8 @@ type ListIssuesParams struct {
 	State     ListIssuesParamState
 	Sort      ListIssuesParamSort
 	Direction ListIssuesParamDirection
+	Labels    string // comma-separated label names
+	Milestone string // milestone number, "*" or "none"
 }
 
 // ListIssuesParamFilter ...
@@ -267,6 +270,8 @@ func listIssuesPath(repo string, params *ListIssuesParams) string {
 		query("state", params.State.String()).
 		query("sort", params.Sort.String()).
 		query("direction", params.Direction.String()).
+		query("labels", params.Labels).
+		query("milestone", params.Milestone).
 		query("per_page", "100").
 		String()
 }
@@ -304,6 +309,43 @@ func (c *client) GetIssue(repo string, issueNumber int) (*Issue, error) {
 	return &r, nil
 }
 
+// CreateIssueParams represents the parameter for CreateIssue API.
+type CreateIssueParams struct {
+	Title     string   `json:"title"`
+	Body      string   `json:"body"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone int      `json:"milestone,omitempty"`
+}
+
+// CreateIssue creates a new issue.
+func (c *client) CreateIssue(repo string, params *CreateIssueParams) (*Issue, error) {
+	var r Issue
+	if err := c.post(c.url(fmt.Sprintf("/repos/%s/issues", repo)), params, &r); err != nil {
+		return nil, fmt.Errorf("CreateIssue %s: %w", fmt.Sprintf("%s/issues", repo), err)
+	}
+	return &r, nil
+}
+
+// UpdateIssueParams represents the parameter for UpdateIssue API.
+type UpdateIssueParams struct {
+	Title     string     `json:"title,omitempty"`
+	Body      string     `json:"body,omitempty"`
+	State     IssueState `json:"state,omitempty"`
+	Labels    []string   `json:"labels,omitempty"`
+	Assignees []string   `json:"assignees,omitempty"`
+	Milestone int        `json:"milestone,omitempty"`
+}
+
+// UpdateIssue updates the issue.
+func (c *client) UpdateIssue(repo string, issueNumber int, params *UpdateIssueParams) (*Issue, error) {
+	var r Issue
+	if err := c.patch(c.url(fmt.Sprintf("/repos/%s/issues/%d", repo, issueNumber)), params, &r); err != nil {
+		return nil, fmt.Errorf("UpdateIssue %s: %w", fmt.Sprintf("%s/issues/%d", repo, issueNumber), err)
+	}
+	return &r, nil
+}
+
 func (c *client) AddAssignees(repo string, issueNumber int, assignees []string) error {
 	var r Issue
 	params := map[string][]string{"assignees": assignees}
@@ -3,6 +3,7 @@ package github
 import (
 	"fmt"
 	"io"
+	"net/url"
 )
 
 // Label represents a label.
@@ -112,3 +113,11 @@ func (c *client) UpdateLabel(repo, name string, params *UpdateLabelParams) (*Lab
 	}
 	return &r, nil
 }
+
+// DeleteLabel deletes the label.
+func (c *client) DeleteLabel(repo, name string) error {
+	if err := c.delete(c.url(fmt.Sprintf("/repos/%s/labels/%s", repo, url.PathEscape(name)))); err != nil {
+		return fmt.Errorf("DeleteLabel %s: %w", fmt.Sprintf("%s/labels/%s", repo, name), err)
+	}
+	return nil
+}
@@ -20,7 +20,8 @@ func NewLogger(opts ...LoggerOption) *Logger {
 	return l
 }
 
-func (l *Logger) preRequest(r *http.Request) {
+// PreRequest calls the pre-request callback.
+func (l *Logger) PreRequest(r *http.Request) {
 	if l.preRequestCallback != nil {
 		l.preRequestCallback(r)
 	}
@@ -33,7 +34,8 @@ func LoggerPreRequest(callback func(*http.Request)) LoggerOption {
 	}
 }
 
-func (l *Logger) postRequest(r *http.Response, err error) {
+// PostRequest calls the post-request callback.
+func (l *Logger) PostRequest(r *http.Response, err error) {
 	if l.postRequestCallback != nil {
 		l.postRequestCallback(r, err)
 	}
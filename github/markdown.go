@@ -0,0 +1,37 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type renderMarkdownParams struct {
+	Text    string `json:"text"`
+	Mode    string `json:"mode"`
+	Context string `json:"context"`
+}
+
+// RenderMarkdown renders the markdown text to HTML, in the context of the repository.
+func (c *client) RenderMarkdown(repo string, text string) (string, error) {
+	bs, err := json.Marshal(&renderMarkdownParams{Text: text, Mode: "gfm", Context: repo})
+	if err != nil {
+		return "", err
+	}
+	req, err := c.request("POST", c.url("/markdown"), bytes.NewReader(bs))
+	if err != nil {
+		return "", err
+	}
+	res, _, err := c.doReq(req)
+	if err != nil {
+		return "", fmt.Errorf("RenderMarkdown %s: %w", repo, err)
+	}
+	defer res.Body.Close()
+
+	bs, err = io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
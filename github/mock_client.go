@@ -2,53 +2,66 @@ package github
 
 // MockClient represents a mock for GitHub client.
 type MockClient struct {
-	getLoginCallback            func() (*User, error)
-	listUsersCallback           func() Users
-	getUserCallback             func(string) (*User, error)
-	listMembersCallback         func(string) Members
-	getRepoCallback             func(string) (*Repo, error)
-	updateRepoCallback          func(string, *UpdateRepoParams) (*Repo, error)
-	listLabelsCallback          func(string) Labels
-	createLabelCallback         func(string, *CreateLabelParams) (*Label, error)
-	updateLabelCallback         func(string, string, *UpdateLabelParams) (*Label, error)
-	listIssuesCallback          func(string, *ListIssuesParams) Issues
-	getIssueCallback            func(string, int) (*Issue, error)
-	addAssigneesCallback        func(string, int, []string) error
-	listCommentsCallback        func(string, int) Comments
-	listEventsCallback          func(string, int) Events
-	listPullReqsCallback        func(string, *ListPullReqsParams) PullReqs
-	getPullReqCallback          func(string, int) (*PullReq, error)
-	listPullReqCommitsCallback  func(string, int) Commits
-	getDiffCallback             func(string, string) (string, error)
-	getCompareCallback          func(string, string, string) (string, error)
-	listReviewsCallback         func(string, int) Reviews
-	getReviewCallback           func(string, int, int) (*Review, error)
-	listReviewCommentsCallback  func(string, int) ReviewComments
-	listProjectsCallback        func(string, *ListProjectsParams) Projects
-	getProjectCallback          func(int) (*Project, error)
-	createProjectCallback       func(string, *CreateProjectParams) (*Project, error)
-	updateProjectCallback       func(int, *UpdateProjectParams) (*Project, error)
-	deleteProjectCallback       func(int) error
-	listProjectColumnsCallback  func(int) ProjectColumns
-	getProjectColumnCallback    func(int) (*ProjectColumn, error)
-	createProjectColumnCallback func(int, string) (*ProjectColumn, error)
-	updateProjectColumnCallback func(int, string) (*ProjectColumn, error)
-	listProjectCardsCallback    func(int) ProjectCards
-	getProjectCardCallback      func(int) (*ProjectCard, error)
-	createProjectCardCallback   func(int, *CreateProjectCardParams) (*ProjectCard, error)
-	updateProjectCardCallback   func(int, *UpdateProjectCardParams) (*ProjectCard, error)
-	moveProjectCardCallback     func(int, *MoveProjectCardParams) (*ProjectCard, error)
-	listMilestonesCallback      func(string, *ListMilestonesParams) Milestones
-	getMilestoneCallback        func(string, int) (*Milestone, error)
-	createMilestoneCallback     func(string, *CreateMilestoneParams) (*Milestone, error)
-	updateMilestoneCallback     func(string, int, *UpdateMilestoneParams) (*Milestone, error)
-	deleteMilestoneCallback     func(string, int) error
-	listHooksCallback           func(string) Hooks
-	getHookCallback             func(string, int) (*Hook, error)
-	createHookCallback          func(string, *CreateHookParams) (*Hook, error)
-	updateHookCallback          func(string, int, *UpdateHookParams) (*Hook, error)
-	importCallback              func(string, *Import) (*ImportResult, error)
-	getImportCallback           func(string, int) (*ImportResult, error)
+	getLoginCallback                   func() (*User, error)
+	listUsersCallback                  func() Users
+	getUserCallback                    func(string) (*User, error)
+	listMembersCallback                func(string) Members
+	listTeamsCallback                  func(string) Teams
+	getTeamCallback                    func(string, string) (*Team, error)
+	getRepoCallback                    func(string) (*Repo, error)
+	updateRepoCallback                 func(string, *UpdateRepoParams) (*Repo, error)
+	listLabelsCallback                 func(string) Labels
+	createLabelCallback                func(string, *CreateLabelParams) (*Label, error)
+	updateLabelCallback                func(string, string, *UpdateLabelParams) (*Label, error)
+	deleteLabelCallback                func(string, string) error
+	listIssuesCallback                 func(string, *ListIssuesParams) Issues
+	getIssueCallback                   func(string, int) (*Issue, error)
+	createIssueCallback                func(string, *CreateIssueParams) (*Issue, error)
+	updateIssueCallback                func(string, int, *UpdateIssueParams) (*Issue, error)
+	listAssigneesCallback              func(string) Users
+	addAssigneesCallback               func(string, int, []string) error
+	listCommentsCallback               func(string, int) Comments
+	createCommentCallback              func(string, int, string) (*Comment, error)
+	listEventsCallback                 func(string, int) Events
+	listIssueReactionsCallback         func(string, int) Reactions
+	listCommentReactionsCallback       func(string, int) Reactions
+	listReviewCommentReactionsCallback func(string, int) Reactions
+	listPullReqsCallback               func(string, *ListPullReqsParams) PullReqs
+	getPullReqCallback                 func(string, int) (*PullReq, error)
+	listPullReqCommitsCallback         func(string, int) Commits
+	getDiffCallback                    func(string, string) (string, error)
+	getCompareCallback                 func(string, string, string) (string, error)
+	renderMarkdownCallback             func(string, string) (string, error)
+	listReviewsCallback                func(string, int) Reviews
+	getReviewCallback                  func(string, int, int) (*Review, error)
+	listReviewCommentsCallback         func(string, int) ReviewComments
+	listProjectsCallback               func(string, *ListProjectsParams) Projects
+	getProjectCallback                 func(int) (*Project, error)
+	createProjectCallback              func(string, *CreateProjectParams) (*Project, error)
+	updateProjectCallback              func(int, *UpdateProjectParams) (*Project, error)
+	deleteProjectCallback              func(int) error
+	listProjectColumnsCallback         func(int) ProjectColumns
+	getProjectColumnCallback           func(int) (*ProjectColumn, error)
+	createProjectColumnCallback        func(int, string) (*ProjectColumn, error)
+	updateProjectColumnCallback        func(int, string) (*ProjectColumn, error)
+	listProjectCardsCallback           func(int) ProjectCards
+	getProjectCardCallback             func(int) (*ProjectCard, error)
+	createProjectCardCallback          func(int, *CreateProjectCardParams) (*ProjectCard, error)
+	updateProjectCardCallback          func(int, *UpdateProjectCardParams) (*ProjectCard, error)
+	moveProjectCardCallback            func(int, *MoveProjectCardParams) (*ProjectCard, error)
+	listMilestonesCallback             func(string, *ListMilestonesParams) Milestones
+	getMilestoneCallback               func(string, int) (*Milestone, error)
+	createMilestoneCallback            func(string, *CreateMilestoneParams) (*Milestone, error)
+	updateMilestoneCallback            func(string, int, *UpdateMilestoneParams) (*Milestone, error)
+	deleteMilestoneCallback            func(string, int) error
+	listHooksCallback                  func(string) Hooks
+	getHookCallback                    func(string, int) (*Hook, error)
+	createHookCallback                 func(string, *CreateHookParams) (*Hook, error)
+	updateHookCallback                 func(string, int, *UpdateHookParams) (*Hook, error)
+	importCallback                     func(string, *Import) (*ImportResult, error)
+	getImportCallback                  func(string, int) (*ImportResult, error)
+	createFileCallback                 func(string, string, *CreateFileParams) (*File, error)
+	downloadCallback                   func(string) ([]byte, error)
 }
 
 // MockClientOption is an option of mock client.
@@ -123,6 +136,36 @@ func MockListMembers(callback func(string) Members) MockClientOption {
 	}
 }
 
+// ListTeams ...
+func (c *MockClient) ListTeams(org string) Teams {
+	if c.listTeamsCallback != nil {
+		return c.listTeamsCallback(org)
+	}
+	panic("MockClient#ListTeams")
+}
+
+// MockListTeams ...
+func MockListTeams(callback func(string) Teams) MockClientOption {
+	return func(c *MockClient) {
+		c.listTeamsCallback = callback
+	}
+}
+
+// GetTeam ...
+func (c *MockClient) GetTeam(org, slug string) (*Team, error) {
+	if c.getTeamCallback != nil {
+		return c.getTeamCallback(org, slug)
+	}
+	panic("MockClient#GetTeam")
+}
+
+// MockGetTeam ...
+func MockGetTeam(callback func(string, string) (*Team, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.getTeamCallback = callback
+	}
+}
+
 // GetRepo ...
 func (c *MockClient) GetRepo(repo string) (*Repo, error) {
 	if c.getRepoCallback != nil {
@@ -198,6 +241,21 @@ func MockUpdateLabel(callback func(string, string, *UpdateLabelParams) (*Label,
 	}
 }
 
+// DeleteLabel ...
+func (c *MockClient) DeleteLabel(repo, name string) error {
+	if c.deleteLabelCallback != nil {
+		return c.deleteLabelCallback(repo, name)
+	}
+	panic("MockClient#DeleteLabel")
+}
+
+// MockDeleteLabel ...
+func MockDeleteLabel(callback func(string, string) error) MockClientOption {
+	return func(c *MockClient) {
+		c.deleteLabelCallback = callback
+	}
+}
+
 // ListIssues ...
 func (c *MockClient) ListIssues(repo string, params *ListIssuesParams) Issues {
 	if c.listIssuesCallback != nil {
@@ -228,6 +286,51 @@ func MockGetIssue(callback func(string, int) (*Issue, error)) MockClientOption {
 	}
 }
 
+// CreateIssue ...
+func (c *MockClient) CreateIssue(repo string, params *CreateIssueParams) (*Issue, error) {
+	if c.createIssueCallback != nil {
+		return c.createIssueCallback(repo, params)
+	}
+	panic("MockClient#CreateIssue")
+}
+
+// MockCreateIssue ...
+func MockCreateIssue(callback func(string, *CreateIssueParams) (*Issue, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createIssueCallback = callback
+	}
+}
+
+// UpdateIssue ...
+func (c *MockClient) UpdateIssue(repo string, issueNumber int, params *UpdateIssueParams) (*Issue, error) {
+	if c.updateIssueCallback != nil {
+		return c.updateIssueCallback(repo, issueNumber, params)
+	}
+	panic("MockClient#UpdateIssue")
+}
+
+// MockUpdateIssue ...
+func MockUpdateIssue(callback func(string, int, *UpdateIssueParams) (*Issue, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.updateIssueCallback = callback
+	}
+}
+
+// ListAssignees ...
+func (c *MockClient) ListAssignees(repo string) Users {
+	if c.listAssigneesCallback != nil {
+		return c.listAssigneesCallback(repo)
+	}
+	panic("MockClient#ListAssignees")
+}
+
+// MockListAssignees ...
+func MockListAssignees(callback func(string) Users) MockClientOption {
+	return func(c *MockClient) {
+		c.listAssigneesCallback = callback
+	}
+}
+
 // AddAssignees ...
 func (c *MockClient) AddAssignees(repo string, issueNumber int, assignees []string) error {
 	if c.addAssigneesCallback != nil {
@@ -258,6 +361,21 @@ func MockListComments(callback func(string, int) Comments) MockClientOption {
 	}
 }
 
+// CreateComment ...
+func (c *MockClient) CreateComment(repo string, issueNumber int, body string) (*Comment, error) {
+	if c.createCommentCallback != nil {
+		return c.createCommentCallback(repo, issueNumber, body)
+	}
+	panic("MockClient#CreateComment")
+}
+
+// MockCreateComment ...
+func MockCreateComment(callback func(string, int, string) (*Comment, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createCommentCallback = callback
+	}
+}
+
 // ListEvents ...
 func (c *MockClient) ListEvents(repo string, issueNumber int) Events {
 	if c.listEventsCallback != nil {
@@ -273,6 +391,51 @@ func MockListEvents(callback func(string, int) Events) MockClientOption {
 	}
 }
 
+// ListIssueReactions ...
+func (c *MockClient) ListIssueReactions(repo string, issueNumber int) Reactions {
+	if c.listIssueReactionsCallback != nil {
+		return c.listIssueReactionsCallback(repo, issueNumber)
+	}
+	panic("MockClient#ListIssueReactions")
+}
+
+// MockListIssueReactions ...
+func MockListIssueReactions(callback func(string, int) Reactions) MockClientOption {
+	return func(c *MockClient) {
+		c.listIssueReactionsCallback = callback
+	}
+}
+
+// ListCommentReactions ...
+func (c *MockClient) ListCommentReactions(repo string, commentID int) Reactions {
+	if c.listCommentReactionsCallback != nil {
+		return c.listCommentReactionsCallback(repo, commentID)
+	}
+	panic("MockClient#ListCommentReactions")
+}
+
+// MockListCommentReactions ...
+func MockListCommentReactions(callback func(string, int) Reactions) MockClientOption {
+	return func(c *MockClient) {
+		c.listCommentReactionsCallback = callback
+	}
+}
+
+// ListReviewCommentReactions ...
+func (c *MockClient) ListReviewCommentReactions(repo string, commentID int) Reactions {
+	if c.listReviewCommentReactionsCallback != nil {
+		return c.listReviewCommentReactionsCallback(repo, commentID)
+	}
+	panic("MockClient#ListReviewCommentReactions")
+}
+
+// MockListReviewCommentReactions ...
+func MockListReviewCommentReactions(callback func(string, int) Reactions) MockClientOption {
+	return func(c *MockClient) {
+		c.listReviewCommentReactionsCallback = callback
+	}
+}
+
 // ListPullReqs ...
 func (c *MockClient) ListPullReqs(repo string, params *ListPullReqsParams) PullReqs {
 	if c.listPullReqsCallback != nil {
@@ -348,6 +511,21 @@ func MockGetCompare(callback func(string, string, string) (string, error)) MockC
 	}
 }
 
+// RenderMarkdown ...
+func (c *MockClient) RenderMarkdown(repo string, text string) (string, error) {
+	if c.renderMarkdownCallback != nil {
+		return c.renderMarkdownCallback(repo, text)
+	}
+	panic("MockClient#RenderMarkdown")
+}
+
+// MockRenderMarkdown ...
+func MockRenderMarkdown(callback func(string, string) (string, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.renderMarkdownCallback = callback
+	}
+}
+
 // ListReviews ...
 func (c *MockClient) ListReviews(repo string, pullNumber int) Reviews {
 	if c.listReviewsCallback != nil {
@@ -767,3 +945,33 @@ func MockGetImport(callback func(string, int) (*ImportResult, error)) MockClient
 		c.getImportCallback = callback
 	}
 }
+
+// CreateFile ...
+func (c *MockClient) CreateFile(repo, path string, params *CreateFileParams) (*File, error) {
+	if c.createFileCallback != nil {
+		return c.createFileCallback(repo, path, params)
+	}
+	panic("MockClient#CreateFile")
+}
+
+// MockCreateFile ...
+func MockCreateFile(callback func(string, string, *CreateFileParams) (*File, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.createFileCallback = callback
+	}
+}
+
+// Download ...
+func (c *MockClient) Download(url string) ([]byte, error) {
+	if c.downloadCallback != nil {
+		return c.downloadCallback(url)
+	}
+	panic("MockClient#Download")
+}
+
+// MockDownload ...
+func MockDownload(callback func(string) ([]byte, error)) MockClientOption {
+	return func(c *MockClient) {
+		c.downloadCallback = callback
+	}
+}
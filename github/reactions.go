@@ -0,0 +1,104 @@
+package github
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Reaction represents a reaction.
+type Reaction struct {
+	ID        int    `json:"id"`
+	User      *User  `json:"user"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReactionSummary represents the summary of the reactions,
+// which is embedded in the issues and comments.
+type ReactionSummary struct {
+	TotalCount int `json:"total_count"`
+}
+
+// Reactions represents a collection of reactions.
+type Reactions <-chan interface{}
+
+// Next emits the next Reaction.
+func (rs Reactions) Next() (*Reaction, error) {
+	for x := range rs {
+		switch x := x.(type) {
+		case error:
+			return nil, x
+		case *Reaction:
+			return x, nil
+		}
+		break
+	}
+	return nil, io.EOF
+}
+
+// ReactionsFromSlice creates Reactions from a slice.
+func ReactionsFromSlice(xs []*Reaction) Reactions {
+	rs := make(chan interface{})
+	go func() {
+		defer close(rs)
+		for _, r := range xs {
+			rs <- r
+		}
+	}()
+	return rs
+}
+
+// ReactionsToSlice collects Reactions.
+func ReactionsToSlice(rs Reactions) ([]*Reaction, error) {
+	xs := []*Reaction{}
+	for {
+		r, err := rs.Next()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			return xs, nil
+		}
+		xs = append(xs, r)
+	}
+}
+
+// ListIssueReactions lists the reactions of an issue.
+func (c *client) ListIssueReactions(repo string, issueNumber int) Reactions {
+	return c.listReactions("ListIssueReactions", fmt.Sprintf("/repos/%s/issues/%d/reactions", repo, issueNumber))
+}
+
+// ListCommentReactions lists the reactions of an issue comment.
+func (c *client) ListCommentReactions(repo string, commentID int) Reactions {
+	return c.listReactions("ListCommentReactions", fmt.Sprintf("/repos/%s/issues/comments/%d/reactions", repo, commentID))
+}
+
+// ListReviewCommentReactions lists the reactions of a review comment.
+func (c *client) ListReviewCommentReactions(repo string, commentID int) Reactions {
+	return c.listReactions("ListReviewCommentReactions", fmt.Sprintf("/repos/%s/pulls/comments/%d/reactions", repo, commentID))
+}
+
+func (c *client) listReactions(name, path string) Reactions {
+	rs := make(chan interface{})
+	go func() {
+		defer close(rs)
+		url := c.url(path + "?per_page=100")
+		for {
+			var xs []*Reaction
+			next, err := c.getList(url, &xs)
+			if err != nil {
+				rs <- fmt.Errorf("%s %s: %w", name, strings.TrimPrefix(path, "/repos/"), err)
+				break
+			}
+			for _, x := range xs {
+				rs <- x
+			}
+			if next == "" {
+				break
+			}
+			url = next
+		}
+	}()
+	return Reactions(rs)
+}
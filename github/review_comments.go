@@ -7,15 +7,16 @@ import (
 
 // ReviewComment represents a review comment.
 type ReviewComment struct {
-	ID          int    `json:"id"`
-	Path        string `json:"path"`
-	Body        string `json:"body"`
-	DiffHunk    string `json:"diff_hunk"`
-	HTMLURL     string `json:"html_url"`
-	User        *User  `json:"user"`
-	InReplyToID int    `json:"in_reply_to_id"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          int              `json:"id"`
+	Path        string           `json:"path"`
+	Body        string           `json:"body"`
+	DiffHunk    string           `json:"diff_hunk"`
+	HTMLURL     string           `json:"html_url"`
+	User        *User            `json:"user"`
+	InReplyToID int              `json:"in_reply_to_id"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
+	Reactions   *ReactionSummary `json:"reactions,omitempty"`
 }
 
 // ReviewComments represents a collection of review comments.
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"fmt"
+	"io"
+)
+
+// Team represents a team.
+type Team struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	HTMLURL string `json:"html_url"`
+}
+
+// Teams represents a collection of teams.
+type Teams <-chan interface{}
+
+// Next emits the next Team.
+func (ts Teams) Next() (*Team, error) {
+	for x := range ts {
+		switch x := x.(type) {
+		case error:
+			return nil, x
+		case *Team:
+			return x, nil
+		}
+		break
+	}
+	return nil, io.EOF
+}
+
+// TeamsFromSlice creates Teams from a slice.
+func TeamsFromSlice(xs []*Team) Teams {
+	ts := make(chan interface{})
+	go func() {
+		defer close(ts)
+		for _, t := range xs {
+			ts <- t
+		}
+	}()
+	return ts
+}
+
+// TeamsToSlice collects Teams.
+func TeamsToSlice(ts Teams) ([]*Team, error) {
+	xs := []*Team{}
+	for {
+		t, err := ts.Next()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			return xs, nil
+		}
+		xs = append(xs, t)
+	}
+}
+
+// ListTeams lists the teams of the organization.
+func (c *client) ListTeams(org string) Teams {
+	ts := make(chan interface{})
+	go func() {
+		defer close(ts)
+		path := c.url(fmt.Sprintf("/orgs/%s/teams?per_page=100", org))
+		for {
+			var xs []*Team
+			next, err := c.getList(path, &xs)
+			if err != nil {
+				if err.Error() != "Not Found" {
+					ts <- fmt.Errorf("ListTeams %s: %w", org, err)
+				}
+				break
+			}
+			for _, x := range xs {
+				ts <- x
+			}
+			if next == "" {
+				break
+			}
+			path = next
+		}
+	}()
+	return Teams(ts)
+}
+
+// GetTeam gets the team of the organization.
+func (c *client) GetTeam(org, slug string) (*Team, error) {
+	var r Team
+	path := fmt.Sprintf("/orgs/%s/teams/%s", org, slug)
+	if err := c.get(c.url(path), &r); err != nil {
+		return nil, fmt.Errorf("GetTeam %s: %w", path, err)
+	}
+	return &r, nil
+}
@@ -9,6 +9,8 @@ import (
 type User struct {
 	Login   string `json:"login"`
 	HTMLURL string `json:"html_url"`
+	Name    string `json:"name,omitempty"`
+	Email   string `json:"email,omitempty"`
 }
 
 // GetLogin ...
@@ -89,6 +91,31 @@ func (c *client) ListUsers() Users {
 	return Users(cs)
 }
 
+// ListAssignees lists the users who can be assigned to the issues of the repository.
+func (c *client) ListAssignees(repo string) Users {
+	cs := make(chan interface{})
+	go func() {
+		defer close(cs)
+		path := c.url(fmt.Sprintf("/repos/%s/assignees?per_page=100", repo))
+		for {
+			var xs []*User
+			next, err := c.getList(path, &xs)
+			if err != nil {
+				cs <- fmt.Errorf("ListAssignees %s: %w", repo, err)
+				break
+			}
+			for _, x := range xs {
+				cs <- x
+			}
+			if next == "" {
+				break
+			}
+			path = next
+		}
+	}()
+	return Users(cs)
+}
+
 // GetUser ...
 func (c *client) GetUser(name string) (*User, error) {
 	var r User
@@ -0,0 +1,92 @@
+package jira
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// New creates a new client for Jira export files.
+// The client implements github.Client so that the issues exported from Jira
+// can be migrated as imported issues. Repositories are specified by the path
+// of the XML or CSV export file.
+func New(opts ...ClientOption) github.Client {
+	c := &client{
+		numbering: NumberingSequential,
+		exports:   make(map[string]*export),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ClientOption is an option of client.
+type ClientOption func(*client)
+
+// ClientNumbering returns a client option to set the numbering policy.
+func ClientNumbering(numbering Numbering) ClientOption {
+	return func(c *client) {
+		c.numbering = numbering
+	}
+}
+
+// ClientURL returns a client option to set the url of Jira.
+// This is required for CSV export files, which do not contain the links.
+func ClientURL(url string) ClientOption {
+	return func(c *client) {
+		c.url = url
+	}
+}
+
+// Numbering is the policy to number the issues.
+type Numbering int
+
+// Numbering ...
+const (
+	// NumberingSequential numbers the issues from 1 in the created order.
+	NumberingSequential Numbering = iota + 1
+	// NumberingKey numbers the issues by the number of the issue keys.
+	NumberingKey
+)
+
+// ParseNumbering parses the numbering policy.
+func ParseNumbering(s string) (Numbering, error) {
+	switch s {
+	case "", "sequential":
+		return NumberingSequential, nil
+	case "key":
+		return NumberingKey, nil
+	default:
+		return 0, fmt.Errorf("unknown numbering: %s (specify sequential or key)", s)
+	}
+}
+
+type client struct {
+	numbering Numbering
+	url       string
+
+	mu      sync.Mutex
+	exports map[string]*export
+}
+
+// getExport loads the export file. The result is cached since every listing
+// method reads from the same file.
+func (c *client) getExport(path string) (*export, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.exports[path]; ok {
+		return e, nil
+	}
+	e, err := loadExport(path, c.url, c.numbering)
+	if err != nil {
+		return nil, err
+	}
+	c.exports[path] = e
+	return e, nil
+}
+
+func unsupported(name string) error {
+	return fmt.Errorf("%s: not supported by Jira export", name)
+}
@@ -0,0 +1,11 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestNew(t *testing.T) {
+	var _ github.Client = New()
+}
@@ -0,0 +1,416 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// export is the loaded content of an export file.
+type export struct {
+	project, name, url string
+	issues             []*issue
+	users              map[string]*user
+}
+
+type issue struct {
+	key, link                       string
+	number                          int
+	summary, description            string
+	status, resolution              string
+	done                            bool
+	reporter, assignee              *user
+	created, updated, resolved      time.Time
+	labels, components, fixVersions []string
+	comments                        []*comment
+}
+
+// user is the user in Jira; the name is the username (or the account id) or
+// the display name when the export has nothing else.
+type user struct {
+	name, displayName string
+}
+
+var loginUnsafePattern = regexp.MustCompile(`[^-\w.]+`)
+
+// login returns the key of the user which is safe for the mentions and the
+// user mapping (the display names in CSV exports contain spaces).
+func (u *user) login() string {
+	return strings.Trim(loginUnsafePattern.ReplaceAllString(u.name, "-"), "-")
+}
+
+type comment struct {
+	id      string
+	author  *user
+	created time.Time
+	body    string
+}
+
+func (i *issue) closed() bool {
+	return i.done || i.resolution != ""
+}
+
+// keyNumber returns the number part of the issue key.
+func keyNumber(key string) (int, error) {
+	i := strings.LastIndexByte(key, '-')
+	if i < 0 {
+		return 0, fmt.Errorf("invalid issue key: %s", key)
+	}
+	n, err := strconv.Atoi(key[i+1:])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid issue key: %s", key)
+	}
+	return n, nil
+}
+
+func loadExport(path, url string, numbering Numbering) (*export, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var e *export
+	switch ext := strings.ToLower(filepath.Ext(path)); {
+	case ext == ".xml", ext != ".csv" && bytes.HasPrefix(bytes.TrimSpace(bs), []byte("<")):
+		e, err = parseXML(bs)
+	default:
+		e, err = parseCSV(bs)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if url != "" {
+		e.url = strings.TrimSuffix(url, "/")
+	}
+	if e.url == "" {
+		return nil, fmt.Errorf("%s: url of Jira is required", path)
+	}
+	for _, i := range e.issues {
+		if i.link == "" {
+			i.link = e.url + "/browse/" + i.key
+		}
+	}
+	if err := e.number(numbering); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	e.rewriteReferences()
+	return e, nil
+}
+
+// number numbers the issues and sorts them by the numbers.
+func (e *export) number(numbering Numbering) error {
+	if len(e.issues) == 0 {
+		return errors.New("no issues found")
+	}
+	for _, i := range e.issues {
+		n, err := keyNumber(i.key)
+		if err != nil {
+			return err
+		}
+		i.number = n
+	}
+	switch numbering {
+	case NumberingKey:
+		sort.SliceStable(e.issues, func(i, j int) bool {
+			return e.issues[i].number < e.issues[j].number
+		})
+		for k := 1; k < len(e.issues); k++ {
+			if e.issues[k-1].number == e.issues[k].number {
+				return fmt.Errorf("duplicate issue number: %s and %s (use sequential numbering)",
+					e.issues[k-1].key, e.issues[k].key)
+			}
+		}
+	default:
+		sort.SliceStable(e.issues, func(i, j int) bool {
+			x, y := e.issues[i], e.issues[j]
+			if !x.created.Equal(y.created) {
+				return x.created.Before(y.created)
+			}
+			return x.number < y.number
+		})
+		for k, i := range e.issues {
+			i.number = k + 1
+			// the key is lost from the link text of the imported issue
+			i.summary = fmt.Sprintf("[%s] %s", i.key, i.summary)
+		}
+	}
+	return nil
+}
+
+var issueKeyRe = regexp.MustCompile(`(^|[^\w/="'-])([A-Z][A-Z0-9_]*-\d+)\b`)
+
+// rewriteReferences rewrites the issue keys in the export to the issue numbers.
+func (e *export) rewriteReferences() {
+	numbers := make(map[string]int, len(e.issues))
+	for _, i := range e.issues {
+		numbers[i.key] = i.number
+	}
+	rewrite := func(s string) string {
+		return issueKeyRe.ReplaceAllStringFunc(s, func(x string) string {
+			m := issueKeyRe.FindStringSubmatch(x)
+			if n, ok := numbers[m[2]]; ok {
+				return m[1] + "#" + strconv.Itoa(n)
+			}
+			return x
+		})
+	}
+	for _, i := range e.issues {
+		i.description = rewrite(i.description)
+		for _, c := range i.comments {
+			c.body = rewrite(c.body)
+		}
+	}
+}
+
+func (e *export) addUser(name, displayName string) *user {
+	if name == "" || name == "-1" {
+		return nil
+	}
+	u, ok := e.users[name]
+	if !ok {
+		u = &user{name: name}
+		e.users[name] = u
+	}
+	if displayName != "" {
+		u.displayName = displayName
+	}
+	return u
+}
+
+// addCSVUser adds the user of the CSV export. The exports of Jira Cloud have
+// the account ids in the separate columns, and the user columns have the
+// display names.
+func (e *export) addCSVUser(id, name string) *user {
+	if id == "" {
+		return e.addUser(name, name)
+	}
+	return e.addUser(id, name)
+}
+
+type xmlExport struct {
+	Channel struct {
+		Items []*xmlItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type xmlItem struct {
+	Link    string `xml:"link"`
+	Project struct {
+		Key  string `xml:"key,attr"`
+		Name string `xml:",chardata"`
+	} `xml:"project"`
+	Key            string `xml:"key"`
+	Summary        string `xml:"summary"`
+	Description    string `xml:"description"`
+	Status         string `xml:"status"`
+	StatusCategory struct {
+		Key string `xml:"key,attr"`
+	} `xml:"statusCategory"`
+	Resolution  string        `xml:"resolution"`
+	Assignee    xmlUser       `xml:"assignee"`
+	Reporter    xmlUser       `xml:"reporter"`
+	Labels      []string      `xml:"labels>label"`
+	Components  []string      `xml:"component"`
+	FixVersions []string      `xml:"fixVersion"`
+	Created     string        `xml:"created"`
+	Updated     string        `xml:"updated"`
+	Resolved    string        `xml:"resolved"`
+	Comments    []*xmlComment `xml:"comments>comment"`
+}
+
+type xmlUser struct {
+	Username  string `xml:"username,attr"`
+	AccountID string `xml:"accountid,attr"`
+	Name      string `xml:",chardata"`
+}
+
+type xmlComment struct {
+	ID      string `xml:"id,attr"`
+	Author  string `xml:"author,attr"`
+	Created string `xml:"created,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// parseXML parses the XML export (RSS) of Jira.
+// The descriptions and comments are rendered in HTML, which GitHub accepts.
+func parseXML(bs []byte) (*export, error) {
+	var x xmlExport
+	if err := xml.Unmarshal(bs, &x); err != nil {
+		return nil, err
+	}
+	e := &export{users: make(map[string]*user)}
+	for _, item := range x.Channel.Items {
+		if e.project == "" {
+			e.project, e.name = item.Project.Key, item.Project.Name
+		}
+		if i := strings.Index(item.Link, "/browse/"); i >= 0 && e.url == "" {
+			e.url = strings.TrimSpace(item.Link[:i])
+		}
+		i := &issue{
+			key:         strings.TrimSpace(item.Key),
+			link:        strings.TrimSpace(item.Link),
+			summary:     strings.TrimSpace(item.Summary),
+			description: strings.TrimSpace(item.Description),
+			status:      strings.TrimSpace(item.Status),
+			done:        item.StatusCategory.Key == "done",
+			reporter:    e.addXMLUser(item.Reporter),
+			assignee:    e.addXMLUser(item.Assignee),
+			labels:      item.Labels,
+			components:  item.Components,
+			fixVersions: item.FixVersions,
+		}
+		if r := strings.TrimSpace(item.Resolution); r != "Unresolved" {
+			i.resolution = r
+		}
+		var err error
+		if i.created, err = parseTime(item.Created); err != nil {
+			return nil, err
+		}
+		if i.updated, err = parseTime(item.Updated); err != nil {
+			return nil, err
+		}
+		if i.resolved, err = parseTime(item.Resolved); err != nil {
+			return nil, err
+		}
+		for _, x := range item.Comments {
+			c := &comment{
+				id:     x.ID,
+				author: e.addUser(x.Author, ""),
+				body:   strings.TrimSpace(x.Body),
+			}
+			if c.created, err = parseTime(x.Created); err != nil {
+				return nil, err
+			}
+			i.comments = append(i.comments, c)
+		}
+		e.issues = append(e.issues, i)
+	}
+	return e, nil
+}
+
+func (e *export) addXMLUser(u xmlUser) *user {
+	name := u.Username
+	if name == "" {
+		name = u.AccountID
+	}
+	return e.addUser(name, strings.TrimSpace(u.Name))
+}
+
+// parseCSV parses the CSV export of Jira. The fields with multiple values
+// (labels, comments and so on) are exported as the columns with the same name.
+// The descriptions and comments are in the wiki markup.
+func parseCSV(bs []byte) (*export, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(bs, []byte("\xef\xbb\xbf"))))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty CSV")
+	}
+	columns := make(map[string][]int)
+	for k, name := range records[0] {
+		columns[name] = append(columns[name], k)
+	}
+	if _, ok := columns["Issue key"]; !ok {
+		return nil, errors.New("column not found: Issue key")
+	}
+	e := &export{users: make(map[string]*user)}
+	for _, record := range records[1:] {
+		values := func(name string) []string {
+			var xs []string
+			for _, k := range columns[name] {
+				if k < len(record) && strings.TrimSpace(record[k]) != "" {
+					xs = append(xs, strings.TrimSpace(record[k]))
+				}
+			}
+			return xs
+		}
+		value := func(name string) string {
+			if xs := values(name); len(xs) > 0 {
+				return xs[0]
+			}
+			return ""
+		}
+		if e.project == "" {
+			e.project, e.name = value("Project key"), value("Project name")
+		}
+		i := &issue{
+			key:         value("Issue key"),
+			summary:     value("Summary"),
+			description: wikiToMarkdown(value("Description")),
+			status:      value("Status"),
+			resolution:  value("Resolution"),
+			done:        value("Status Category") == "Done",
+			reporter:    e.addCSVUser(value("Reporter Id"), value("Reporter")),
+			assignee:    e.addCSVUser(value("Assignee Id"), value("Assignee")),
+			labels:      values("Labels"),
+			components:  values("Component/s"),
+			fixVersions: values("Fix Version/s"),
+		}
+		if i.created, err = parseTime(value("Created")); err != nil {
+			return nil, err
+		}
+		if i.updated, err = parseTime(value("Updated")); err != nil {
+			return nil, err
+		}
+		if i.resolved, err = parseTime(value("Resolved")); err != nil {
+			return nil, err
+		}
+		for _, x := range values("Comment") {
+			// the comments are exported as created;author;body
+			xs := strings.SplitN(x, ";", 3)
+			if len(xs) != 3 {
+				return nil, fmt.Errorf("invalid comment of %s: %s", i.key, x)
+			}
+			c := &comment{author: e.addUser(xs[1], ""), body: wikiToMarkdown(xs[2])}
+			if c.created, err = parseTime(xs[0]); err != nil {
+				return nil, err
+			}
+			i.comments = append(i.comments, c)
+		}
+		e.issues = append(e.issues, i)
+	}
+	return e, nil
+}
+
+var timeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06 15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+}
+
+// parseTime parses the time in the export. The times without the time zone
+// (in CSV exports) are regarded as UTC.
+func parseTime(s string) (time.Time, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", s)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
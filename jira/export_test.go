@@ -0,0 +1,254 @@
+package jira
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+const testXML = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="0.92">
+  <channel>
+    <title>Jira</title>
+    <link>https://jira.example.com/issues/?jql=project+%3D+PRJ</link>
+    <item>
+      <title>[PRJ-3] Second issue</title>
+      <link>https://jira.example.com/browse/PRJ-3</link>
+      <project id="10000" key="PRJ">Example Project</project>
+      <description>&lt;p&gt;Follows up PRJ-1.&lt;/p&gt;</description>
+      <key id="10003">PRJ-3</key>
+      <summary>Second issue</summary>
+      <status id="3">In Progress</status>
+      <statusCategory id="4" key="indeterminate"/>
+      <resolution id="-1">Unresolved</resolution>
+      <assignee username="-1">Unassigned</assignee>
+      <reporter username="user2">User Two</reporter>
+      <created>Tue, 19 Nov 2019 09:00:00 +0900</created>
+      <updated>Tue, 19 Nov 2019 10:00:00 +0900</updated>
+      <fixVersion>1.1</fixVersion>
+    </item>
+    <item>
+      <title>[PRJ-1] First issue</title>
+      <link>https://jira.example.com/browse/PRJ-1</link>
+      <project id="10000" key="PRJ">Example Project</project>
+      <description>&lt;p&gt;Description&lt;/p&gt;</description>
+      <key id="10001">PRJ-1</key>
+      <summary>First issue</summary>
+      <status id="6">Closed</status>
+      <statusCategory id="3" key="done"/>
+      <resolution id="1">Fixed</resolution>
+      <assignee username="user1">User One</assignee>
+      <reporter username="user2">User Two</reporter>
+      <labels>
+        <label>bug</label>
+      </labels>
+      <created>Mon, 18 Nov 2019 12:00:00 +0000</created>
+      <updated>Mon, 18 Nov 2019 14:00:00 +0000</updated>
+      <resolved>Mon, 18 Nov 2019 13:30:00 +0000</resolved>
+      <fixVersion>1.0</fixVersion>
+      <fixVersion>1.1</fixVersion>
+      <component>Backend</component>
+      <comments>
+        <comment id="10100" author="user1" created="Mon, 18 Nov 2019 13:00:00 +0000">&lt;p&gt;Fixed in PRJ-3 and OTHER-1&lt;/p&gt;</comment>
+      </comments>
+    </item>
+  </channel>
+</rss>
+`
+
+const testCSV = "\xef\xbb\xbf" + `Summary,Issue key,Issue Type,Status,Project key,Project name,Resolution,Assignee,Reporter,Created,Updated,Resolved,Labels,Labels,Component/s,Fix Version/s,Description,Comment,Comment
+First issue,PRJ-1,Bug,Closed,PRJ,Example Project,Fixed,user1,user2,18/Nov/19 12:00 PM,18/Nov/19 2:00 PM,18/Nov/19 1:30 PM,bug,,Backend,1.0,"*Steps*
+# run",18/Nov/19 1:00 PM;user1;Fixed in PRJ-3,
+Second issue,PRJ-3,Task,Open,PRJ,Example Project,,,user2,19/Nov/19 12:00 AM,19/Nov/19 1:00 AM,,,,,,,,
+`
+
+func writeTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func listIssues(t *testing.T, cli github.Client, path string) []*github.Issue {
+	issues, err := github.IssuesToSlice(cli.ListIssues(path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return issues
+}
+
+func TestClientXML(t *testing.T) {
+	path := writeTestFile(t, "export.xml", testXML)
+	cli := New()
+
+	repo, err := cli.GetRepo(path)
+	assert.Nil(t, err)
+	assert.Equal(t, &github.Repo{
+		Name:        "PRJ",
+		FullName:    "PRJ",
+		Description: "Example Project",
+		HTMLURL:     "https://jira.example.com/projects/PRJ",
+		Private:     true,
+	}, repo)
+
+	issues := listIssues(t, cli, path)
+	assert.Len(t, issues, 2)
+	assert.Equal(t, &github.Issue{
+		ID:        1,
+		Number:    1,
+		Title:     "[PRJ-1] First issue",
+		State:     github.IssueStateClosed,
+		Body:      "<p>Description</p>",
+		HTMLURL:   "https://jira.example.com/browse/PRJ-1",
+		User:      &github.User{Login: "user2", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user2"},
+		Assignee:  &github.User{Login: "user1", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user1"},
+		Assignees: []*github.User{{Login: "user1", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user1"}},
+		CreatedAt: "2019-11-18T12:00:00Z",
+		UpdatedAt: "2019-11-18T14:00:00Z",
+		ClosedAt:  "2019-11-18T13:30:00Z",
+		Labels: []*github.Label{
+			{Name: "bug", Color: "ededed"},
+			{Name: "component: Backend", Color: "c5def5"},
+			{Name: "resolution: Fixed", Color: "d4c5f9"},
+		},
+		Milestone: &github.Milestone{Title: "1.1"},
+	}, issues[0])
+	assert.Equal(t, "[PRJ-3] Second issue", issues[1].Title)
+	assert.Equal(t, github.IssueStateOpen, issues[1].State)
+	assert.Equal(t, "<p>Follows up #1.</p>", issues[1].Body)
+	assert.Equal(t, "2019-11-19T00:00:00Z", issues[1].CreatedAt)
+	assert.Nil(t, issues[1].Assignee)
+	assert.Equal(t, []*github.Label{{Name: "status: In Progress", Color: "fbca04"}}, issues[1].Labels)
+
+	comments, err := github.CommentsToSlice(cli.ListComments(path, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, []*github.Comment{
+		{
+			Body:      "<p>Fixed in #2 and OTHER-1</p>",
+			HTMLURL:   "https://jira.example.com/browse/PRJ-1?focusedCommentId=10100",
+			User:      &github.User{Login: "user1", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user1"},
+			CreatedAt: "2019-11-18T13:00:00Z",
+			UpdatedAt: "2019-11-18T13:00:00Z",
+		},
+	}, comments)
+
+	labels, err := github.LabelsToSlice(cli.ListLabels(path))
+	assert.Nil(t, err)
+	assert.Equal(t, []*github.Label{
+		{Name: "bug", Color: "ededed"},
+		{Name: "component: Backend", Color: "c5def5"},
+		{Name: "resolution: Fixed", Color: "d4c5f9"},
+		{Name: "status: In Progress", Color: "fbca04"},
+	}, labels)
+
+	milestones, err := github.MilestonesToSlice(cli.ListMilestones(path, nil))
+	assert.Nil(t, err)
+	assert.Equal(t, []*github.Milestone{
+		{ID: 1, Number: 1, Title: "1.0", State: github.MilestoneStateClosed},
+		{ID: 2, Number: 2, Title: "1.1", State: github.MilestoneStateOpen},
+	}, milestones)
+}
+
+func TestClientXMLNumberingKey(t *testing.T) {
+	path := writeTestFile(t, "export.xml", testXML)
+	cli := New(ClientNumbering(NumberingKey))
+
+	issues := listIssues(t, cli, path)
+	assert.Len(t, issues, 2)
+	assert.Equal(t, 1, issues[0].Number)
+	assert.Equal(t, "First issue", issues[0].Title)
+	assert.Equal(t, 3, issues[1].Number)
+	assert.Equal(t, "Second issue", issues[1].Title)
+	assert.Equal(t, "<p>Follows up #1.</p>", issues[1].Body)
+
+	comments, err := github.CommentsToSlice(cli.ListComments(path, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, "<p>Fixed in #3 and OTHER-1</p>", comments[0].Body)
+
+	_, err = cli.GetIssue(path, 2)
+	assert.EqualError(t, err, "GetIssue "+path+"#2: issue not found: 2")
+}
+
+func TestClientCSV(t *testing.T) {
+	path := writeTestFile(t, "export.csv", testCSV)
+
+	_, err := New().GetRepo(path)
+	assert.EqualError(t, err, "GetRepo "+path+": "+path+": url of Jira is required")
+
+	cli := New(ClientURL("https://jira.example.com/"), ClientNumbering(NumberingKey))
+	issues := listIssues(t, cli, path)
+	assert.Len(t, issues, 2)
+	assert.Equal(t, &github.Issue{
+		ID:        1,
+		Number:    1,
+		Title:     "First issue",
+		State:     github.IssueStateClosed,
+		Body:      "**Steps**\n1. run",
+		HTMLURL:   "https://jira.example.com/browse/PRJ-1",
+		User:      &github.User{Login: "user2", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user2"},
+		Assignee:  &github.User{Login: "user1", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user1"},
+		Assignees: []*github.User{{Login: "user1", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user1"}},
+		CreatedAt: "2019-11-18T12:00:00Z",
+		UpdatedAt: "2019-11-18T14:00:00Z",
+		ClosedAt:  "2019-11-18T13:30:00Z",
+		Labels: []*github.Label{
+			{Name: "bug", Color: "ededed"},
+			{Name: "component: Backend", Color: "c5def5"},
+			{Name: "resolution: Fixed", Color: "d4c5f9"},
+		},
+		Milestone: &github.Milestone{Title: "1.0"},
+	}, issues[0])
+	assert.Equal(t, 3, issues[1].Number)
+	assert.Equal(t, github.IssueStateOpen, issues[1].State)
+	assert.Equal(t, []*github.Label{{Name: "status: Open", Color: "fbca04"}}, issues[1].Labels)
+
+	comments, err := github.CommentsToSlice(cli.ListComments(path, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, []*github.Comment{
+		{
+			Body:      "Fixed in #3",
+			HTMLURL:   "https://jira.example.com/browse/PRJ-1",
+			User:      &github.User{Login: "user1", HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=user1"},
+			CreatedAt: "2019-11-18T13:00:00Z",
+			UpdatedAt: "2019-11-18T13:00:00Z",
+		},
+	}, comments)
+}
+
+func TestToUser(t *testing.T) {
+	e := &export{url: "https://jira.example.com"}
+	assert.Equal(t, &github.User{
+		Login:   "John-Doe-dev-ops",
+		HTMLURL: "https://jira.example.com/secure/ViewProfile.jspa?name=John+Doe%2Bdev%26ops",
+	}, toUser(e, &user{name: "John Doe+dev&ops"}))
+	assert.Nil(t, toUser(e, nil))
+}
+
+func TestClientCSVAccountIDs(t *testing.T) {
+	path := writeTestFile(t, "export.csv", `Summary,Issue key,Project key,Project name,Status,Assignee,Assignee Id,Reporter,Reporter Id,Created,Comment
+First issue,PRJ-1,PRJ,Example Project,Open,Jane Doe,5b10ac8d82e05b22cc7d4ef5,John Smith,,18/Nov/19 12:00 PM,18/Nov/19 1:00 PM;5b10ac8d82e05b22cc7d4ef5;Done
+`)
+	cli := New(ClientURL("https://jira.example.com"))
+	issues := listIssues(t, cli, path)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", issues[0].Assignee.Login)
+	assert.Equal(t, "John-Smith", issues[0].User.Login)
+
+	comments, err := github.CommentsToSlice(cli.ListComments(path, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", comments[0].User.Login)
+
+	user, err := cli.GetUser("5b10ac8d82e05b22cc7d4ef5")
+	assert.Nil(t, err)
+	assert.Equal(t, "Jane Doe", user.Name)
+	user, err = cli.GetUser("John-Smith")
+	assert.Nil(t, err)
+	assert.Equal(t, "John Smith", user.Name)
+	_, err = cli.GetUser("user1")
+	assert.EqualError(t, err, "GetUser user1: Not Found")
+}
@@ -0,0 +1,251 @@
+package jira
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// The labels are prefixed to tell from the labels in Jira.
+const (
+	componentLabelPrefix  = "component: "
+	statusLabelPrefix     = "status: "
+	resolutionLabelPrefix = "resolution: "
+)
+
+func toUser(e *export, u *user) *github.User {
+	if u == nil {
+		return nil
+	}
+	return &github.User{
+		Login:   u.login(),
+		HTMLURL: e.url + "/secure/ViewProfile.jspa?name=" + url.QueryEscape(u.name),
+	}
+}
+
+// labelNames returns the labels of the issue, the components and the status
+// for the open issues, or the resolution for the closed issues.
+func (i *issue) labelNames() []string {
+	xs := append([]string{}, i.labels...)
+	for _, c := range i.components {
+		xs = append(xs, componentLabelPrefix+c)
+	}
+	if !i.closed() {
+		if i.status != "" {
+			xs = append(xs, statusLabelPrefix+i.status)
+		}
+	} else if i.resolution != "" {
+		xs = append(xs, resolutionLabelPrefix+i.resolution)
+	}
+	return xs
+}
+
+func labelColor(name string) string {
+	switch {
+	case strings.HasPrefix(name, componentLabelPrefix):
+		return "c5def5"
+	case strings.HasPrefix(name, statusLabelPrefix):
+		return "fbca04"
+	case strings.HasPrefix(name, resolutionLabelPrefix):
+		return "d4c5f9"
+	default:
+		return "ededed"
+	}
+}
+
+func toIssue(e *export, i *issue) *github.Issue {
+	x := &github.Issue{
+		ID:        i.number,
+		Number:    i.number,
+		Title:     i.summary,
+		State:     github.IssueStateOpen,
+		Body:      i.description,
+		HTMLURL:   i.link,
+		User:      toUser(e, i.reporter),
+		Assignee:  toUser(e, i.assignee),
+		CreatedAt: formatTime(i.created),
+		UpdatedAt: formatTime(i.updated),
+	}
+	if x.User == nil {
+		x.User = &github.User{Login: "ghost"}
+	}
+	if x.Assignee != nil {
+		x.Assignees = []*github.User{x.Assignee}
+	}
+	if i.closed() {
+		x.State = github.IssueStateClosed
+		x.ClosedAt = formatTime(i.resolved)
+		if x.ClosedAt == "" {
+			x.ClosedAt = x.UpdatedAt
+		}
+	}
+	for _, name := range i.labelNames() {
+		x.Labels = append(x.Labels, &github.Label{Name: name, Color: labelColor(name)})
+	}
+	// GitHub issues have only one milestone, so take the latest one
+	if len(i.fixVersions) > 0 {
+		x.Milestone = &github.Milestone{Title: i.fixVersions[len(i.fixVersions)-1]}
+	}
+	return x
+}
+
+// ListIssues lists the issues in the export, sorted by the numbers.
+func (c *client) ListIssues(repo string, _ *github.ListIssuesParams) github.Issues {
+	is := make(chan interface{})
+	go func() {
+		defer close(is)
+		e, err := c.getExport(repo)
+		if err != nil {
+			is <- fmt.Errorf("ListIssues %s: %w", repo, err)
+			return
+		}
+		for _, i := range e.issues {
+			is <- toIssue(e, i)
+		}
+	}()
+	return github.Issues(is)
+}
+
+func (c *client) getIssue(repo string, issueNumber int) (*export, *issue, error) {
+	e, err := c.getExport(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, i := range e.issues {
+		if i.number == issueNumber {
+			return e, i, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("issue not found: %d", issueNumber)
+}
+
+// GetIssue gets the issue.
+func (c *client) GetIssue(repo string, issueNumber int) (*github.Issue, error) {
+	e, i, err := c.getIssue(repo, issueNumber)
+	if err != nil {
+		return nil, fmt.Errorf("GetIssue %s#%d: %w", repo, issueNumber, err)
+	}
+	return toIssue(e, i), nil
+}
+
+// ListComments lists the comments of the issue.
+func (c *client) ListComments(repo string, issueNumber int) github.Comments {
+	cs := make(chan interface{})
+	go func() {
+		defer close(cs)
+		e, i, err := c.getIssue(repo, issueNumber)
+		if err != nil {
+			cs <- fmt.Errorf("ListComments %s#%d: %w", repo, issueNumber, err)
+			return
+		}
+		for _, x := range i.comments {
+			y := &github.Comment{
+				Body:      x.body,
+				HTMLURL:   i.link,
+				User:      toUser(e, x.author),
+				CreatedAt: formatTime(x.created),
+				UpdatedAt: formatTime(x.created),
+			}
+			if y.User == nil {
+				y.User = &github.User{Login: "ghost"}
+			}
+			if x.id != "" {
+				y.HTMLURL += "?focusedCommentId=" + x.id
+			}
+			cs <- y
+		}
+	}()
+	return github.Comments(cs)
+}
+
+// ListEvents returns no events since the exports contain no history.
+func (c *client) ListEvents(string, int) github.Events {
+	return github.EventsFromSlice([]*github.Event{})
+}
+
+// ListLabels lists the labels used in the issues.
+func (c *client) ListLabels(repo string) github.Labels {
+	ls := make(chan interface{})
+	go func() {
+		defer close(ls)
+		e, err := c.getExport(repo)
+		if err != nil {
+			ls <- fmt.Errorf("ListLabels %s: %w", repo, err)
+			return
+		}
+		names := make(map[string]bool)
+		var xs []string
+		for _, i := range e.issues {
+			for _, name := range i.labelNames() {
+				if !names[name] {
+					names[name] = true
+					xs = append(xs, name)
+				}
+			}
+		}
+		sort.Strings(xs)
+		for _, name := range xs {
+			ls <- &github.Label{Name: name, Color: labelColor(name)}
+		}
+	}()
+	return github.Labels(ls)
+}
+
+// ListMilestones lists the fix versions as the milestones, numbered in the
+// order of appearance. The milestones are closed when all the issues are closed.
+func (c *client) ListMilestones(repo string, _ *github.ListMilestonesParams) github.Milestones {
+	ms := make(chan interface{})
+	go func() {
+		defer close(ms)
+		e, err := c.getExport(repo)
+		if err != nil {
+			ms <- fmt.Errorf("ListMilestones %s: %w", repo, err)
+			return
+		}
+		for _, m := range listMilestones(e) {
+			ms <- m
+		}
+	}()
+	return github.Milestones(ms)
+}
+
+func listMilestones(e *export) []*github.Milestone {
+	var xs []*github.Milestone
+	milestones := make(map[string]*github.Milestone)
+	for _, i := range e.issues {
+		for _, v := range i.fixVersions {
+			m, ok := milestones[v]
+			if !ok {
+				m = &github.Milestone{
+					ID:     len(xs) + 1,
+					Number: len(xs) + 1,
+					Title:  v,
+					State:  github.MilestoneStateClosed,
+				}
+				milestones[v] = m
+				xs = append(xs, m)
+			}
+			if !i.closed() {
+				m.State = github.MilestoneStateOpen
+			}
+		}
+	}
+	return xs
+}
+
+// GetMilestone gets the milestone.
+func (c *client) GetMilestone(repo string, milestoneNumber int) (*github.Milestone, error) {
+	e, err := c.getExport(repo)
+	if err != nil {
+		return nil, fmt.Errorf("GetMilestone %s: %w", repo, err)
+	}
+	for _, m := range listMilestones(e) {
+		if m.Number == milestoneNumber {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("GetMilestone %s: milestone not found: %d", repo, milestoneNumber)
+}
@@ -0,0 +1,128 @@
+package jira
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	codeBlockStartRe = regexp.MustCompile(`^\s*\{(code|noformat)(?::([^}|]*))?[^}]*\}(.*)$`)
+	codeBlockEndRe   = regexp.MustCompile(`^(.*?)\{(code|noformat)\}\s*$`)
+	headingRe        = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	blockQuoteRe     = regexp.MustCompile(`^bq\.\s+(.*)$`)
+	listRe           = regexp.MustCompile(`^([*#-]+)\s+(.*)$`)
+	tableHeaderRe    = regexp.MustCompile(`^\s*\|\|(.*)\|\|\s*$`)
+	tableRowRe       = regexp.MustCompile(`^\s*\|(.*)\|\s*$`)
+	monospaceRe      = regexp.MustCompile(`\{\{(.+?)\}\}`)
+	boldRe           = regexp.MustCompile(`(^|[\s(\[])\*(\S|\S.*?\S)\*($|[\s).,:;!?\]])`)
+	italicRe         = regexp.MustCompile(`(^|[\s(\[])_(\S|\S.*?\S)_($|[\s).,:;!?\]])`)
+	strikeRe         = regexp.MustCompile(`(^|[\s(\[])-(\S|\S.*?\S)-($|[\s).,:;!?\]])`)
+	mentionRe        = regexp.MustCompile(`\[~([^\]]+)\]`)
+	linkRe           = regexp.MustCompile(`\[([^\]|]+)\|([^\]]+)\]`)
+	bareLinkRe       = regexp.MustCompile(`\[((?:https?|mailto):[^\]|]+)\]`)
+	imageRe          = regexp.MustCompile(`!([^\s!|]+)(?:\|[^!]*)?!`)
+	colorRe          = regexp.MustCompile(`\{color(?::[^}]*)?\}`)
+)
+
+// wikiToMarkdown converts the Jira wiki markup to Markdown.
+func wikiToMarkdown(src string) string {
+	var xs []string
+	var inCode, inQuote bool
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		if inCode {
+			if m := codeBlockEndRe.FindStringSubmatch(line); m != nil {
+				if m[1] != "" {
+					xs = append(xs, m[1])
+				}
+				xs = append(xs, "```")
+				inCode = false
+				continue
+			}
+			xs = append(xs, line)
+			continue
+		}
+		if m := codeBlockStartRe.FindStringSubmatch(line); m != nil {
+			xs = append(xs, "```"+strings.TrimSpace(m[2]))
+			if rest := m[3]; rest != "" {
+				if n := codeBlockEndRe.FindStringSubmatch(rest); n != nil {
+					xs = append(xs, n[1], "```")
+					continue
+				}
+				xs = append(xs, rest)
+			}
+			inCode = true
+			continue
+		}
+		if strings.TrimSpace(line) == "{quote}" {
+			inQuote = !inQuote
+			continue
+		}
+		line = convertWikiLine(line)
+		if inQuote {
+			line = "> " + line
+		}
+		xs = append(xs, line)
+	}
+	if inCode {
+		xs = append(xs, "```")
+	}
+	return strings.Join(xs, "\n")
+}
+
+func convertWikiLine(line string) string {
+	if strings.TrimSpace(line) == "----" {
+		return "---"
+	}
+	if m := headingRe.FindStringSubmatch(line); m != nil {
+		return strings.Repeat("#", int(m[1][0]-'0')) + " " + convertWikiInline(m[2])
+	}
+	if m := blockQuoteRe.FindStringSubmatch(line); m != nil {
+		return "> " + convertWikiInline(m[1])
+	}
+	if m := tableHeaderRe.FindStringSubmatch(line); m != nil {
+		cells := strings.Split(m[1], "||")
+		separators := make([]string, len(cells))
+		for i, cell := range cells {
+			cells[i] = convertWikiInline(strings.TrimSpace(cell))
+			separators[i] = "---"
+		}
+		return "| " + strings.Join(cells, " | ") + " |\n| " + strings.Join(separators, " | ") + " |"
+	}
+	if m := tableRowRe.FindStringSubmatch(line); m != nil && !strings.Contains(m[1], "[") {
+		cells := strings.Split(m[1], "|")
+		for i, cell := range cells {
+			cells[i] = convertWikiInline(strings.TrimSpace(cell))
+		}
+		return "| " + strings.Join(cells, " | ") + " |"
+	}
+	if m := listRe.FindStringSubmatch(line); m != nil && (m[1][0] != '-' || len(m[1]) == 1) {
+		marker := "-"
+		if m[1][len(m[1])-1] == '#' {
+			marker = "1."
+		}
+		return strings.Repeat("  ", len(m[1])-1) + marker + " " + convertWikiInline(m[2])
+	}
+	return convertWikiInline(line)
+}
+
+func convertWikiInline(s string) string {
+	// protect the monospace texts from the other conversions
+	var codes []string
+	s = monospaceRe.ReplaceAllStringFunc(s, func(x string) string {
+		codes = append(codes, "`"+x[2:len(x)-2]+"`")
+		return "\x00" + strconv.Itoa(len(codes)-1) + "\x00"
+	})
+	s = colorRe.ReplaceAllString(s, "")
+	s = mentionRe.ReplaceAllString(s, "@$1")
+	s = linkRe.ReplaceAllString(s, "[$1]($2)")
+	s = bareLinkRe.ReplaceAllString(s, "<$1>")
+	s = imageRe.ReplaceAllString(s, "![]($1)")
+	s = boldRe.ReplaceAllString(s, "$1**$2**$3")
+	s = italicRe.ReplaceAllString(s, "$1*$2*$3")
+	s = strikeRe.ReplaceAllString(s, "$1~~$2~~$3")
+	for i, code := range codes {
+		s = strings.ReplaceAll(s, "\x00"+strconv.Itoa(i)+"\x00", code)
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package jira
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWikiToMarkdown(t *testing.T) {
+	testCases := []struct {
+		name, src, expected string
+	}{
+		{
+			name:     "heading",
+			src:      "h1. Title\nh3. Sub *title*",
+			expected: "# Title\n### Sub **title**",
+		},
+		{
+			name:     "inline",
+			src:      "This is *bold*, _italic_, -deleted- and {{mono *space*}}.",
+			expected: "This is **bold**, *italic*, ~~deleted~~ and `mono *space*`.",
+		},
+		{
+			name:     "not emphasis",
+			src:      "a*b*c snake_case_name 2 * 3 * 4 well-known-name",
+			expected: "a*b*c snake_case_name 2 * 3 * 4 well-known-name",
+		},
+		{
+			name:     "links",
+			src:      "See [the docs|https://example.com/docs], [https://example.com] and [~user1]. !screenshot.png|thumbnail!",
+			expected: "See [the docs](https://example.com/docs), <https://example.com> and @user1. ![](screenshot.png)",
+		},
+		{
+			name:     "lists",
+			src:      "* item 1\n** item 1-1\n# first\n## second\n- item",
+			expected: "- item 1\n  - item 1-1\n1. first\n  1. second\n- item",
+		},
+		{
+			name:     "table",
+			src:      "||name||value||\n|foo|*1*|",
+			expected: "| name | value |\n| --- | --- |\n| foo | **1** |",
+		},
+		{
+			name:     "code block",
+			src:      "{code:java}\nint *x* = 1;\n{code}\n{noformat}\nh1. raw\n{noformat}",
+			expected: "```java\nint *x* = 1;\n```\n```\nh1. raw\n```",
+		},
+		{
+			name:     "quote",
+			src:      "bq. quoted\n{quote}\nline *1*\nline 2\n{quote}",
+			expected: "> quoted\n> line **1**\n> line 2",
+		},
+		{
+			name:     "color",
+			src:      "{color:red}warning{color}",
+			expected: "warning",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, wikiToMarkdown(tc.src))
+		})
+	}
+}
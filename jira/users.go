@@ -0,0 +1,70 @@
+package jira
+
+import (
+	"fmt"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// GetLogin is not supported since the client reads the export files.
+func (c *client) GetLogin() (*github.User, error) {
+	return nil, unsupported("GetLogin")
+}
+
+// ListUsers is not supported since the users depend on the export files.
+func (c *client) ListUsers() github.Users {
+	us := make(chan interface{}, 1)
+	us <- unsupported("ListUsers")
+	close(us)
+	return github.Users(us)
+}
+
+// GetUser gets the user in the loaded export files, with the display name.
+func (c *client) GetUser(login string) (*github.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range c.exports {
+		for _, u := range e.users {
+			if u.login() == login {
+				user := toUser(e, u)
+				user.Name = u.displayName
+				return user, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("GetUser %s: Not Found", login)
+}
+
+// ListMembers returns no members since Jira has no organizations.
+func (c *client) ListMembers(string) github.Members {
+	return github.MembersFromSlice([]*github.Member{})
+}
+
+// ListTeams returns no teams since Jira has no organizations.
+func (c *client) ListTeams(string) github.Teams {
+	return github.TeamsFromSlice([]*github.Team{})
+}
+
+// GetTeam is not supported since Jira has no organizations.
+func (c *client) GetTeam(string, string) (*github.Team, error) {
+	return nil, unsupported("GetTeam")
+}
+
+// GetRepo gets the project of the export.
+func (c *client) GetRepo(repo string) (*github.Repo, error) {
+	e, err := c.getExport(repo)
+	if err != nil {
+		return nil, fmt.Errorf("GetRepo %s: %w", repo, err)
+	}
+	return &github.Repo{
+		Name:        e.project,
+		FullName:    e.project,
+		Description: e.name,
+		HTMLURL:     e.url + "/projects/" + e.project,
+		Private:     true,
+	}, nil
+}
+
+func (c *client) UpdateRepo(string, *github.UpdateRepoParams) (*github.Repo, error) {
+	return nil, unsupported("UpdateRepo")
+}
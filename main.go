@@ -1,13 +1,18 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/itchyny/github-migrator/bitbucket"
 	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/jira"
 	"github.com/itchyny/github-migrator/migrator"
+	"github.com/itchyny/github-migrator/plan"
 	"github.com/itchyny/github-migrator/repo"
 )
 
@@ -21,14 +26,68 @@ func main() {
 }
 
 func run(args []string) error {
-	if len(args) != 2 {
-		return fmt.Errorf("usage: %s <source> <target>", name)
-	}
-	mig, err := createMigrator(args[0], args[1])
-	if err != nil {
-		return err
+	switch {
+	case len(args) == 2:
+		mig, err := createMigrator(args[0], args[1], "")
+		if err != nil {
+			return err
+		}
+		return mig.Migrate()
+	case len(args) == 4 && args[0] == "build":
+		mig, err := createMigrator(args[1], args[2], args[3])
+		if err != nil {
+			return err
+		}
+		return mig.Migrate()
+	case len(args) >= 3 && args[0] == "merge":
+		mig, err := createMerger(args[2:], args[1])
+		if err != nil {
+			return err
+		}
+		return mig.Migrate()
+	case len(args) == 3 && args[0] == "split":
+		mig, err := createSplitter(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return mig.Migrate()
+	case len(args) == 3 && args[0] == "apply":
+		targetCli, err := createTargetClient()
+		if err != nil {
+			return err
+		}
+		return plan.Apply(args[1], repo.New(targetCli, args[2]))
+	case len(args) == 3 && args[0] == "archive":
+		arc, err := createArchiver(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return arc.Archive()
+	case len(args) == 3 && args[0] == "validate-users":
+		v, err := createUserValidator(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return v.ValidateUsers()
+	case len(args) == 4 && args[0] == "suggest-users":
+		s, err := createUserSuggester(args[1], args[2], args[3])
+		if err != nil {
+			return err
+		}
+		return s.SuggestUsers()
+	case len(args) <= 2 && len(args) > 0 && args[0] == "test-rules":
+		return testRules(args[1:])
+	default:
+		return fmt.Errorf(`usage: %[1]s <source> <target>
+       %[1]s build <source> <target> <dir>
+       %[1]s apply <dir> <target>
+       %[1]s merge <target> <source>...
+       %[1]s split <source> <index>
+       %[1]s archive <source> <dir>
+       %[1]s validate-users <source> <target>
+       %[1]s suggest-users <source> <target> <file>
+       %[1]s test-rules [<file>]`, name)
 	}
-	return mig.Migrate()
 }
 
 func createGitHubClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client, error) {
@@ -41,27 +100,7 @@ func createGitHubClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client,
 		endpoint = "https://api.github.com"
 	}
 	proxy := os.Getenv(proxyEnv)
-	cli := github.New(
-		token, endpoint, proxy,
-		github.ClientLogger(
-			github.NewLogger(
-				github.LoggerPreRequest(func(req *http.Request) {
-					fmt.Printf("===> %s: %s\n", req.Method, req.URL)
-				}),
-				github.LoggerPostRequest(func(res *http.Response, err error) {
-					if err != nil {
-						var suffix string
-						if res != nil {
-							suffix = fmt.Sprintf(": %s: %s", res.Request.Method, res.Request.URL)
-						}
-						fmt.Printf("<=== %s%s\n", err, suffix)
-						return
-					}
-					fmt.Printf("<=== %s: %s: %s\n", res.Status, res.Request.Method, res.Request.URL)
-				}),
-			),
-		),
-	)
+	cli := github.New(token, endpoint, proxy, github.ClientLogger(createLogger()))
 	user, err := cli.GetLogin()
 	if err != nil {
 		return nil, fmt.Errorf("%s (or you may want to set %s)", err, endpointEnv)
@@ -70,35 +109,387 @@ func createGitHubClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client,
 	return cli, nil
 }
 
-func createMigrator(sourcePath, targetPath string) (migrator.Migrator, error) {
-	sourceCli, err := createGitHubClient(
-		"GITHUB_MIGRATOR_SOURCE_API_TOKEN",
-		"GITHUB_MIGRATOR_SOURCE_API_ENDPOINT",
-		"GITHUB_MIGRATOR_SOURCE_PROXY_URL",
-	)
+func createBitbucketClient(tokenEnv, endpointEnv, proxyEnv string) (github.Client, error) {
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		return nil, fmt.Errorf("Bitbucket Server token not found (specify %s)", tokenEnv)
+	}
+	endpoint := os.Getenv(endpointEnv)
+	if endpoint == "" {
+		return nil, fmt.Errorf("Bitbucket Server endpoint not found (specify %s)", endpointEnv)
+	}
+	proxy := os.Getenv(proxyEnv)
+	cli := bitbucket.New(token, endpoint, proxy, bitbucket.ClientLogger(createLogger()))
+	user, err := cli.GetLogin()
 	if err != nil {
 		return nil, err
 	}
-	targetCli, err := createGitHubClient(
+	fmt.Printf("[<>] login succeeded: %s\n", user.Login)
+	return cli, nil
+}
+
+func createJiraClient(urlEnv, numberingEnv string) (github.Client, error) {
+	numbering, err := jira.ParseNumbering(os.Getenv(numberingEnv))
+	if err != nil {
+		return nil, err
+	}
+	return jira.New(
+		jira.ClientURL(os.Getenv(urlEnv)),
+		jira.ClientNumbering(numbering),
+	), nil
+}
+
+func createLogger() *github.Logger {
+	return github.NewLogger(
+		github.LoggerPreRequest(func(req *http.Request) {
+			fmt.Printf("===> %s: %s\n", req.Method, req.URL)
+		}),
+		github.LoggerPostRequest(func(res *http.Response, err error) {
+			if err != nil {
+				var suffix string
+				if res != nil {
+					suffix = fmt.Sprintf(": %s: %s", res.Request.Method, res.Request.URL)
+				}
+				fmt.Printf("<=== %s%s\n", err, suffix)
+				return
+			}
+			fmt.Printf("<=== %s: %s: %s\n", res.Status, res.Request.Method, res.Request.URL)
+		}),
+	)
+}
+
+func createSourceClient() (github.Client, error) {
+	switch sourceType := os.Getenv("GITHUB_MIGRATOR_SOURCE_TYPE"); sourceType {
+	case "", "github":
+		return createGitHubClient(
+			"GITHUB_MIGRATOR_SOURCE_API_TOKEN",
+			"GITHUB_MIGRATOR_SOURCE_API_ENDPOINT",
+			"GITHUB_MIGRATOR_SOURCE_PROXY_URL",
+		)
+	case "bitbucket":
+		return createBitbucketClient(
+			"GITHUB_MIGRATOR_SOURCE_API_TOKEN",
+			"GITHUB_MIGRATOR_SOURCE_API_ENDPOINT",
+			"GITHUB_MIGRATOR_SOURCE_PROXY_URL",
+		)
+	case "jira":
+		return createJiraClient(
+			"GITHUB_MIGRATOR_JIRA_URL",
+			"GITHUB_MIGRATOR_JIRA_NUMBERING",
+		)
+	default:
+		return nil, fmt.Errorf("unknown source type: %s (specify github, bitbucket or jira)", sourceType)
+	}
+}
+
+func createTargetClient() (github.Client, error) {
+	return createGitHubClient(
 		"GITHUB_MIGRATOR_TARGET_API_TOKEN",
 		"GITHUB_MIGRATOR_TARGET_API_ENDPOINT",
 		"GITHUB_MIGRATOR_TARGET_PROXY_URL",
 	)
+}
+
+func createMigrator(sourcePath, targetPath, planDir string) (migrator.Migrator, error) {
+	sourceCli, err := createSourceClient()
+	if err != nil {
+		return nil, err
+	}
+	targetCli, err := createTargetClient()
+	if err != nil {
+		return nil, err
+	}
+	if planDir != "" {
+		if targetCli, err = plan.NewRecorder(targetCli, planDir); err != nil {
+			return nil, err
+		}
+	}
+	c, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := createMigratorOptions(targetCli, c, planDir)
+	if err != nil {
+		return nil, err
+	}
+	userMapping, err := createUserMapping()
+	if err != nil {
+		return nil, err
+	}
+	source := repo.New(sourceCli, sourcePath)
+	target := repo.New(targetCli, targetPath)
+	return migrator.New(source, target, userMapping, opts...), nil
+}
+
+func createMerger(sourcePaths []string, targetPath string) (migrator.Migrator, error) {
+	sourceCli, err := createSourceClient()
+	if err != nil {
+		return nil, err
+	}
+	targetCli, err := createTargetClient()
+	if err != nil {
+		return nil, err
+	}
+	c, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	merge := migrator.Merge{LabelPrefix: "repo:", MapFile: "issue-numbers.json"}
+	if c.Renumbering != nil {
+		if c.Renumbering.Offset > 0 {
+			return nil, errors.New("renumbering offset is not supported for merging repositories")
+		}
+		merge.MapFile = c.Renumbering.MapFile
+		c.Renumbering = nil
+	}
+	if c.Merge != nil {
+		merge.Order = c.Merge.Order
+		if c.Merge.LabelPrefix != nil {
+			merge.LabelPrefix = *c.Merge.LabelPrefix
+		}
+	}
+	opts, err := createMigratorOptions(targetCli, c, "")
+	if err != nil {
+		return nil, err
+	}
+	userMapping, err := createUserMapping()
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]*repo.Repo, len(sourcePaths))
+	for i, sourcePath := range sourcePaths {
+		sources[i] = repo.New(sourceCli, sourcePath)
+	}
+	target := repo.New(targetCli, targetPath)
+	return migrator.NewMerger(sources, target, userMapping, merge, opts...), nil
+}
+
+func createSplitter(sourcePath, indexPath string) (migrator.Migrator, error) {
+	c, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.RoutingRules) == 0 {
+		return nil, errors.New("no routing rules in the config (specify GITHUB_MIGRATOR_CONFIG)")
+	}
+	if c.Renumbering != nil {
+		return nil, errors.New("renumbering is not supported for splitting a repository")
+	}
+	sourceCli, err := createSourceClient()
+	if err != nil {
+		return nil, err
+	}
+	targetCli, err := createTargetClient()
+	if err != nil {
+		return nil, err
+	}
+	opts, err := createMigratorOptions(targetCli, c, "")
+	if err != nil {
+		return nil, err
+	}
+	userMapping, err := createUserMapping()
+	if err != nil {
+		return nil, err
+	}
+	var targets []*repo.Repo
+	for _, targetPath := range c.RoutingRules.Targets() {
+		targets = append(targets, repo.New(targetCli, targetPath))
+	}
+	source := repo.New(sourceCli, sourcePath)
+	return migrator.NewSplitter(source, targets, c.RoutingRules, indexPath, userMapping, opts...), nil
+}
+
+func createUserValidator(sourcePath, targetPath string) (migrator.UserValidator, error) {
+	sourceCli, err := createSourceClient()
+	if err != nil {
+		return nil, err
+	}
+	targetCli, err := createTargetClient()
+	if err != nil {
+		return nil, err
+	}
+	userMapping, err := createUserMapping()
 	if err != nil {
 		return nil, err
 	}
 	source := repo.New(sourceCli, sourcePath)
 	target := repo.New(targetCli, targetPath)
-	return migrator.New(source, target, createUserMapping()), nil
+	return migrator.NewUserValidator(source, target, userMapping), nil
 }
 
-func createUserMapping() map[string]string {
+func createUserSuggester(sourcePath, targetPath, path string) (migrator.UserSuggester, error) {
+	sourceCli, err := createSourceClient()
+	if err != nil {
+		return nil, err
+	}
+	targetCli, err := createTargetClient()
+	if err != nil {
+		return nil, err
+	}
+	userMapping, err := createUserMapping()
+	if err != nil {
+		return nil, err
+	}
+	source := repo.New(sourceCli, sourcePath)
+	target := repo.New(targetCli, targetPath)
+	return migrator.NewUserSuggester(source, target, userMapping, path), nil
+}
+
+func createArchiver(sourcePath, dir string) (migrator.Archiver, error) {
+	format, err := migrator.ParseArchiveFormat(os.Getenv("GITHUB_MIGRATOR_ARCHIVE_FORMAT"))
+	if err != nil {
+		return nil, err
+	}
+	sourceCli, err := createSourceClient()
+	if err != nil {
+		return nil, err
+	}
+	c, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return migrator.NewArchiver(repo.New(sourceCli, sourcePath), dir, format, createRenderingOptions(c)...), nil
+}
+
+func createMigratorOptions(targetCli github.Client, c *config, planDir string) ([]migrator.MigratorOption, error) {
+	opts := createRenderingOptions(c)
+	switch targetAPI := os.Getenv("GITHUB_MIGRATOR_TARGET_API"); targetAPI {
+	case "", "import":
+	case "issues":
+		opts = append(opts, migrator.MigratorIssuesAPI())
+	default:
+		return nil, fmt.Errorf("unknown target api: %s (specify import or issues)", targetAPI)
+	}
+	droppedAssigneesPath := os.Getenv("GITHUB_MIGRATOR_DROPPED_ASSIGNEES_REPORT")
+	if droppedAssigneesPath == "" {
+		droppedAssigneesPath = "dropped-assignees.json"
+	}
+	opts = append(opts, migrator.MigratorDroppedAssignees(droppedAssigneesPath))
+	switch reactions := os.Getenv("GITHUB_MIGRATOR_REACTIONS"); reactions {
+	case "", "counts":
+	case "users":
+		opts = append(opts, migrator.MigratorReactionUsers())
+	case "none":
+		opts = append(opts, migrator.MigratorSkipReactions())
+	default:
+		return nil, fmt.Errorf("unknown reactions: %s (specify counts, users or none)", reactions)
+	}
+	policy, err := migrator.ParseMentionPolicy(os.Getenv("GITHUB_MIGRATOR_MENTION_POLICY"))
+	if err != nil {
+		return nil, err
+	}
+	if policy != migrator.MentionPolicyKeep {
+		var allowlist []string
+		for _, name := range strings.Split(os.Getenv("GITHUB_MIGRATOR_MENTION_ALLOWLIST"), ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				allowlist = append(allowlist, name)
+			}
+		}
+		opts = append(opts, migrator.MigratorMentionPolicy(policy, allowlist))
+	}
+	if len(c.LabelMapping) > 0 {
+		opts = append(opts, migrator.MigratorLabelMapping(c.LabelMapping))
+	}
+	if len(c.MilestoneMapping) > 0 {
+		opts = append(opts, migrator.MigratorMilestoneMapping(c.MilestoneMapping))
+	}
+	if len(c.RepoMapping) > 0 {
+		opts = append(opts, migrator.MigratorRepoMapping(c.RepoMapping))
+	}
+	if c.HostLinks != nil {
+		opts = append(opts, migrator.MigratorHostLinks(c.HostLinks.Report))
+	}
+	if c.Prune != nil {
+		opts = append(opts, migrator.MigratorPrune(*c.Prune))
+	}
+	if c.ValidateUsers != nil {
+		opts = append(opts, migrator.MigratorValidateUsers(c.ValidateUsers.AllowUnmapped))
+	}
+	if c.Renumbering != nil {
+		opts = append(opts, migrator.MigratorRenumbering(migrator.Renumbering{
+			Offset:  c.Renumbering.Offset,
+			MapFile: c.Renumbering.MapFile,
+		}))
+	}
+	if c.Conflicts != nil {
+		opts = append(opts, migrator.MigratorConflictPolicies(*c.Conflicts))
+	}
+	if c.TeamMapping != nil {
+		opts = append(opts, migrator.MigratorTeamMapping(c.TeamMapping.Teams, c.TeamMapping.Derive))
+	}
+	if path := os.Getenv("GITHUB_MIGRATOR_COMMIT_MAP"); path != "" {
+		cm, err := migrator.LoadCommitMap(path)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, migrator.MigratorCommitMap(cm))
+	}
+	if assetsPath := os.Getenv("GITHUB_MIGRATOR_ATTACHMENTS_REPO"); assetsPath != "" {
+		manifestPath := os.Getenv("GITHUB_MIGRATOR_ATTACHMENTS_MANIFEST")
+		if manifestPath == "" {
+			manifestPath = "attachments.json"
+		}
+		// the attachments are not uploaded until applied, so the manifest of
+		// the build phase is written to the directory
+		if planDir != "" {
+			buildManifestPath := filepath.Join(planDir, "attachments.json")
+			if err := copyFile(manifestPath, buildManifestPath); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			manifestPath = buildManifestPath
+		}
+		reportPath := os.Getenv("GITHUB_MIGRATOR_ATTACHMENTS_REPORT")
+		if reportPath == "" {
+			reportPath = "failed-attachments.json"
+		}
+		opts = append(opts, migrator.MigratorAttachments(
+			repo.New(targetCli, assetsPath),
+			os.Getenv("GITHUB_MIGRATOR_ATTACHMENTS_BRANCH"),
+			manifestPath, reportPath,
+		))
+	}
+	return opts, nil
+}
+
+// createRenderingOptions creates the options shared with the archiver.
+func createRenderingOptions(c *config) []migrator.MigratorOption {
+	var opts []migrator.MigratorOption
+	if templatesDir := os.Getenv("GITHUB_MIGRATOR_TEMPLATES_DIR"); templatesDir != "" {
+		opts = append(opts, migrator.MigratorTemplates(templatesDir))
+	}
+	if len(c.RewriteRules) > 0 {
+		opts = append(opts, migrator.MigratorRewriteRules(c.RewriteRules))
+	}
+	if c.Redaction != nil {
+		opts = append(opts, migrator.MigratorRedaction(c.Redaction.Patterns, c.Redaction.Report))
+	}
+	return opts
+}
+
+// createUserMapping loads the user mapping file, and the mapping in the
+// environment variable takes precedence over the file.
+func createUserMapping() (map[string]string, error) {
 	m := make(map[string]string)
+	if path := os.Getenv("GITHUB_MIGRATOR_USER_MAPPING_FILE"); path != "" {
+		um, err := migrator.LoadUserMapping(path)
+		if err != nil {
+			return nil, err
+		}
+		m = um.Map()
+	}
 	for _, src := range strings.Split(os.Getenv("GITHUB_MIGRATOR_USER_MAPPING"), ",") {
 		xs := strings.Split(strings.TrimSpace(src), ":")
 		if len(xs) == 2 && len(xs[0]) > 0 && len(xs[1]) > 0 {
 			m[xs[0]] = xs[1]
 		}
 	}
-	return m
+	return m, nil
+}
+
+func copyFile(src, dst string) error {
+	bs, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, bs, 0644)
 }
@@ -0,0 +1,272 @@
+package migrator
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// Archiver represents an archiver of a repository.
+type Archiver interface {
+	Archive() error
+}
+
+// ArchiveFormat represents the format of the archive files.
+type ArchiveFormat int
+
+// ArchiveFormat constants.
+const (
+	ArchiveFormatMarkdown ArchiveFormat = iota
+	ArchiveFormatHTML
+)
+
+// ParseArchiveFormat parses the archive format.
+func ParseArchiveFormat(s string) (ArchiveFormat, error) {
+	switch s {
+	case "", "markdown":
+		return ArchiveFormatMarkdown, nil
+	case "html":
+		return ArchiveFormatHTML, nil
+	default:
+		return 0, fmt.Errorf("unknown archive format: %s (specify markdown or html)", s)
+	}
+}
+
+func (f ArchiveFormat) ext() string {
+	if f == ArchiveFormatHTML {
+		return ".html"
+	}
+	return ".md"
+}
+
+// NewArchiver creates a new Archiver, which writes the issues and pull requests
+// of the source repository to the directory, one file for each issue.
+// The files are rendered in the same way as the migration, and the links
+// between the issues point to the local files. The options related to the
+// rendering (like MigratorTemplates) are respected.
+func NewArchiver(source *repo.Repo, dir string, format ArchiveFormat, opts ...MigratorOption) Archiver {
+	m := &migrator{source: source, target: source, renderTimestamps: true}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return &archiver{migrator: m, dir: dir, format: format}
+}
+
+type archiver struct {
+	*migrator
+	dir    string
+	format ArchiveFormat
+}
+
+type archiveEntry struct {
+	issue *github.Issue
+	imp   *github.Import
+}
+
+// Archive the repository.
+func (a *archiver) Archive() (err error) {
+	if err = os.MkdirAll(a.dir, 0755); err != nil {
+		return err
+	}
+	if a.sourceRepo, err = a.source.Get(); err != nil {
+		return err
+	}
+	// check the markdown API up front, which is not supported by some sources
+	if a.format == ArchiveFormatHTML {
+		if _, err = a.source.RenderMarkdown(escapeMarkdown(a.sourceRepo.FullName)); err != nil {
+			return fmt.Errorf("the html format requires the markdown API of the source (specify markdown): %w", err)
+		}
+	}
+	if err = a.loadTemplates(); err != nil {
+		return err
+	}
+	// the users and links are resolved against the source repository
+	a.targetRepo = a.sourceRepo
+	var filters, titleFilters []commentFilter
+	if a.redaction != nil {
+		filter, err := a.newRedactionFilter()
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		titleFilters = append(titleFilters, filter)
+	}
+	if len(a.rewriteRules) > 0 {
+		filter, err := a.newRewriteRulesFilter()
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		titleFilters = append(titleFilters, filter)
+	}
+	a.commentFilters = newCommentFilters(append(filters,
+		newLocalLinkFilter(a.sourceRepo, a.format.ext()),
+	)...)
+	a.titleFilters = newCommentFilters(titleFilters...)
+	if a.targetMembers, err = github.MembersToSlice(a.source.ListMembers()); err != nil {
+		return err
+	}
+	milestones, err := github.MilestonesToSlice(
+		a.source.ListMilestones(&github.ListMilestonesParams{
+			State: github.ListMilestonesParamStateAll,
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	a.milestoneByTitle = make(map[string]*github.Milestone, len(milestones))
+	for _, l := range milestones {
+		a.milestoneByTitle[l.Title] = l
+	}
+	var entries []*archiveEntry
+	issues := a.source.ListIssues()
+	for {
+		issue, err := issues.Next()
+		if err != nil {
+			if err != io.EOF {
+				return err
+			}
+			break
+		}
+		fmt.Printf("[=>] archiving an issue: %s\n", issue.HTMLURL)
+		imp, err := a.buildIssueImport(issue, true)
+		if err != nil {
+			return err
+		}
+		entry := &archiveEntry{issue, imp}
+		if err := a.writeFile(strconv.Itoa(issue.Number), buildIssueTitle(entry), a.buildIssuePage(entry)); err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+	fmt.Printf("[>>] creating the index: %s\n", a.sourceRepo.FullName)
+	return a.writeFile("index", a.sourceRepo.FullName, a.buildIndexPage(entries))
+}
+
+func (a *archiver) writeFile(name, title, body string) error {
+	if a.format == ArchiveFormatHTML {
+		s, err := a.source.RenderMarkdown(body)
+		if err != nil {
+			return err
+		}
+		body = fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+</head>
+<body>
+%s</body>
+</html>
+`, html.EscapeString(title), s)
+	}
+	return os.WriteFile(filepath.Join(a.dir, name+a.format.ext()), []byte(body), 0644)
+}
+
+func (a *archiver) buildIssuePage(e *archiveEntry) string {
+	s := new(strings.Builder)
+	s.WriteString("# " + escapeMarkdown(buildIssueTitle(e)) + "\n\n")
+	s.WriteString(buildIssueSummary(e) + "\n\n")
+	s.WriteString(e.imp.Issue.Body)
+	for _, c := range sortImportComments(e.imp.Comments) {
+		s.WriteString("\n\n" + c.Body)
+	}
+	return s.String()
+}
+
+func (a *archiver) buildIndexPage(entries []*archiveEntry) string {
+	s := new(strings.Builder)
+	s.WriteString("# " + escapeMarkdown(a.sourceRepo.FullName) + "\n\n")
+	if a.sourceRepo.Description != "" {
+		s.WriteString(escapeMarkdown(a.sourceRepo.Description) + "\n\n")
+	}
+	s.WriteString("Archived from " + a.sourceRepo.HTMLURL + ".\n\n")
+	s.WriteString("| # | Title | Type | State | Labels | Milestone |\n")
+	s.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, e := range entries {
+		var milestone string
+		if e.issue.Milestone != nil {
+			milestone = e.issue.Milestone.Title
+		}
+		s.WriteString(fmt.Sprintf("| [#%d](%d%s) | %s | %s | %s | %s | %s |\n",
+			e.issue.Number, e.issue.Number, a.format.ext(),
+			escapeTableCell(e.imp.Issue.Title), e.issue.Type(), e.issue.State,
+			escapeTableCell(buildLabelsCell(e.imp.Issue.Labels)), escapeTableCell(milestone)))
+	}
+	return s.String()
+}
+
+func buildIssueTitle(e *archiveEntry) string {
+	return fmt.Sprintf("%s #%d", e.imp.Issue.Title, e.issue.Number)
+}
+
+func buildIssueSummary(e *archiveEntry) string {
+	xs := []string{"**" + e.issue.State.String() + "** " + e.issue.Type().String()}
+	if len(e.imp.Issue.Labels) > 0 {
+		xs = append(xs, "labels: "+buildLabelsCell(e.imp.Issue.Labels))
+	}
+	if e.issue.Milestone != nil {
+		xs = append(xs, "milestone: "+escapeMarkdown(e.issue.Milestone.Title))
+	}
+	return strings.Join(xs, ", ")
+}
+
+func buildLabelsCell(labels []string) string {
+	xs := make([]string, len(labels))
+	for i, l := range labels {
+		xs[i] = "`" + strings.ReplaceAll(l, "`", "'") + "`"
+	}
+	return strings.Join(xs, " ")
+}
+
+var markdownSpecialPattern = regexp.MustCompile("[\\\\`*_{}\\[\\]<>#|]")
+
+func escapeMarkdown(s string) string {
+	return markdownSpecialPattern.ReplaceAllString(s, `\$0`)
+}
+
+func escapeTableCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// newLocalLinkFilter rewrites the links to the issues and pull requests,
+// including the references like #123 and owner/repo#123, to the local files.
+// This filter is applied to the bodies only, so the links to the original
+// issues in the headers are kept.
+func newLocalLinkFilter(sourceRepo *github.Repo, ext string) commentFilter {
+	issueURLFilter := newIssueURLFilter(sourceRepo, ext)
+	referencePattern := regexp.MustCompile(
+		`(^|[\s(])(#|` + regexp.QuoteMeta(sourceRepo.FullName) + `#)(\d+)\b`,
+	)
+	return commentFilter(func(src string) string {
+		src = issueURLFilter(src)
+		xs := strings.Split(src, "\n")
+		var inCode bool
+		for i, x := range xs {
+			if strings.HasPrefix(strings.TrimSpace(x), "```") {
+				inCode = !inCode
+			}
+			if !inCode {
+				xs[i] = referencePattern.ReplaceAllString(x, "$1[$2$3]($3"+ext+")")
+			}
+		}
+		return strings.Join(xs, "\n")
+	})
+}
+
+func newIssueURLFilter(sourceRepo *github.Repo, ext string) commentFilter {
+	issueURLPattern := regexp.MustCompile(
+		regexp.QuoteMeta(sourceRepo.HTMLURL) + `/(?:issues|pull)/(\d+)\b([^/]|$)`,
+	)
+	return commentFilter(func(src string) string {
+		return issueURLPattern.ReplaceAllString(src, "$1"+ext+"$2")
+	})
+}
@@ -0,0 +1,155 @@
+package migrator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func newArchiveTestClient() *github.MockClient {
+	issues := []*github.Issue{
+		{
+			Number:    1,
+			Title:     "Example title 1",
+			State:     github.IssueStateClosed,
+			Body:      "See #2, example/test#2 and http://localhost/example/test/pull/2.",
+			User:      &github.User{Login: "sample-user-1"},
+			HTMLURL:   "http://localhost/example/test/issues/1",
+			CreatedAt: "2020-01-01T01:00:00Z",
+		},
+		{
+			Number:    2,
+			Title:     "Example title 2",
+			State:     github.IssueStateOpen,
+			Body:      "```\n#1\n```",
+			User:      &github.User{Login: "sample-user-2"},
+			HTMLURL:   "http://localhost/example/test/issues/2",
+			Labels:    []*github.Label{{Name: "bug"}},
+			Milestone: &github.Milestone{Title: "v1.0"},
+			CreatedAt: "2020-01-02T01:00:00Z",
+		},
+	}
+	return github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{
+				Name:     "test",
+				FullName: "example/test",
+				HTMLURL:  "http://localhost/example/test",
+			}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice([]*github.Member{})
+		}),
+		github.MockGetUser(func(name string) (*github.User, error) {
+			return &github.User{Login: name}, nil
+		}),
+		github.MockListMilestones(func(string, *github.ListMilestonesParams) github.Milestones {
+			return github.MilestonesFromSlice([]*github.Milestone{{Number: 1, Title: "v1.0"}})
+		}),
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice(issues)
+		}),
+		github.MockListComments(func(_ string, number int) github.Comments {
+			if number != 1 {
+				return github.CommentsFromSlice([]*github.Comment{})
+			}
+			return github.CommentsFromSlice([]*github.Comment{
+				{
+					Body:      "Example comment",
+					User:      &github.User{Login: "sample-user-2"},
+					CreatedAt: "2020-01-01T02:00:00Z",
+				},
+			})
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice([]*github.Event{})
+		}),
+		github.MockRenderMarkdown(func(_, text string) (string, error) {
+			return "<pre>" + text + "</pre>\n", nil
+		}),
+	)
+}
+
+func TestArchiverMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	source := repo.New(newArchiveTestClient(), "example/test")
+	assert.Nil(t, NewArchiver(source, dir, ArchiveFormatMarkdown).Archive())
+
+	bs, err := os.ReadFile(filepath.Join(dir, "1.md"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bs), "# Example title 1 \\#1\n\n**closed** issue\n\n")
+	assert.Contains(t, string(bs), "@sample-user-1 created the original issue on Jan 1, 2020, 01:00 UTC<br>")
+	assert.Contains(t, string(bs), "See [#2](2.md), [example/test#2](2.md) and 2.md.")
+	assert.Contains(t, string(bs), "@sample-user-2 commented on Jan 1, 2020, 02:00 UTC")
+
+	bs, err = os.ReadFile(filepath.Join(dir, "2.md"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bs), "**open** issue, labels: `bug`, milestone: v1.0\n\n")
+	assert.Contains(t, string(bs), "```\n#1\n```")
+
+	bs, err = os.ReadFile(filepath.Join(dir, "index.md"))
+	assert.Nil(t, err)
+	assert.Equal(t, `# example/test
+
+Archived from http://localhost/example/test.
+
+| # | Title | Type | State | Labels | Milestone |
+| --- | --- | --- | --- | --- | --- |
+| [#1](1.md) | Example title 1 | issue | closed |  |  |
+| [#2](2.md) | Example title 2 | issue | open | `+"`bug`"+` | v1.0 |
+`, string(bs))
+}
+
+func TestArchiverRewriteRules(t *testing.T) {
+	dir := t.TempDir()
+	source := repo.New(newArchiveTestClient(), "example/test")
+	assert.Nil(t, NewArchiver(source, dir, ArchiveFormatMarkdown,
+		MigratorRewriteRules(RewriteRules{{Literal: "title 1", Replace: "subject 1"}}),
+	).Archive())
+
+	bs, err := os.ReadFile(filepath.Join(dir, "1.md"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bs), "# Example subject 1 \\#1\n\n")
+
+	bs, err = os.ReadFile(filepath.Join(dir, "index.md"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bs), "| [#1](1.md) | Example subject 1 | issue | closed |  |  |\n")
+}
+
+func TestArchiverHTML(t *testing.T) {
+	dir := t.TempDir()
+	source := repo.New(newArchiveTestClient(), "example/test")
+	assert.Nil(t, NewArchiver(source, dir, ArchiveFormatHTML).Archive())
+
+	bs, err := os.ReadFile(filepath.Join(dir, "1.html"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bs), "See [#2](2.html), [example/test#2](2.html) and 2.html.")
+	assert.Contains(t, string(bs), `imported from <a href="http://localhost/example/test/issues/1">example/test#1</a>`)
+
+	bs, err = os.ReadFile(filepath.Join(dir, "2.html"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(bs), "<title>Example title 2 #2</title>")
+
+	_, err = os.Stat(filepath.Join(dir, "index.html"))
+	assert.Nil(t, err)
+}
+
+func TestArchiverHTMLUnsupported(t *testing.T) {
+	source := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/test", HTMLURL: "http://localhost/example/test"}, nil
+		}),
+		github.MockRenderMarkdown(func(string, string) (string, error) {
+			return "", errors.New("RenderMarkdown: not supported by Jira export")
+		}),
+	), "example/test")
+	assert.EqualError(t, NewArchiver(source, t.TempDir(), ArchiveFormatHTML).Archive(),
+		"the html format requires the markdown API of the source (specify markdown): "+
+			"RenderMarkdown: not supported by Jira export")
+}
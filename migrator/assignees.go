@@ -0,0 +1,149 @@
+package migrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// MigratorDroppedAssignees returns a migrator option to write the assignees
+// which cannot be assigned on the target to the report file.
+func MigratorDroppedAssignees(reportPath string) MigratorOption {
+	return func(m *migrator) {
+		m.droppedAssigneesPath = reportPath
+	}
+}
+
+type droppedAssignee struct {
+	Issue    string `json:"issue"`
+	Assignee string `json:"assignee"`
+}
+
+// queueAssignees queues the assignees of the source issue which are not
+// assigned on the target (the import API accepts only one assignee), to add
+// them after the issues are imported. The assignees of the existing issues
+// are queued as well, in case the previous run was interrupted.
+func (m *migrator) queueAssignees(issue *github.Issue, assigned ...string) {
+	var xs []string
+	for _, login := range assigneeLogins(issue) {
+		login = m.mapLogin(login)
+		if !containsString(assigned, login) && !containsString(xs, login) {
+			xs = append(xs, login)
+		}
+	}
+	if len(xs) == 0 {
+		return
+	}
+	if m.pendingAssignees == nil {
+		m.pendingAssignees = make(map[int][]string)
+	}
+	m.pendingAssignees[m.lookupIssueNumber(issue.Number)] = xs
+}
+
+// migrateAssignees adds the queued assignees to the imported issues.
+// The users who cannot be assigned on the target are dropped and reported.
+func (m *migrator) migrateAssignees() error {
+	if len(m.pendingAssignees) == 0 {
+		return nil
+	}
+	users, err := github.UsersToSlice(m.target.ListAssignees())
+	if err != nil {
+		return err
+	}
+	assignables := make(map[string]bool, len(users))
+	for _, u := range users {
+		assignables[u.Login] = true
+	}
+	numbers := make([]int, 0, len(m.pendingAssignees))
+	for number := range m.pendingAssignees {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	for _, number := range numbers {
+		var xs []string
+		for _, login := range m.pendingAssignees[number] {
+			if !assignables[login] {
+				issue := fmt.Sprintf("%s/issues/%d", m.targetRepo.HTMLURL, number)
+				fmt.Printf("[--] dropping an assignee: @%s of %s (not assignable)\n", login, issue)
+				m.droppedAssignees = append(m.droppedAssignees, &droppedAssignee{Issue: issue, Assignee: login})
+				continue
+			}
+			xs = append(xs, login)
+		}
+		if len(xs) == 0 {
+			continue
+		}
+		fmt.Printf("[|>] adding assignees: %s/issues/%d (@%s)\n",
+			m.targetRepo.HTMLURL, number, strings.Join(xs, ", @"))
+		if err := m.target.AddAssignees(number, xs); err != nil {
+			return err
+		}
+	}
+	return m.reportDroppedAssignees()
+}
+
+// reportDroppedAssignees shows the dropped assignees, and writes them to the
+// report file (merged with the report of the previous runs).
+func (m *migrator) reportDroppedAssignees() error {
+	if len(m.droppedAssignees) == 0 {
+		return nil
+	}
+	issues := make(map[string][]string)
+	var logins []string
+	for _, d := range m.droppedAssignees {
+		if _, ok := issues[d.Assignee]; !ok {
+			logins = append(logins, d.Assignee)
+		}
+		issues[d.Assignee] = append(issues[d.Assignee], "#"+d.Issue[strings.LastIndexByte(d.Issue, '/')+1:])
+	}
+	sort.Strings(logins)
+	xs := make([]string, len(logins))
+	for i, login := range logins {
+		xs[i] = fmt.Sprintf("@%s (%s)", login, strings.Join(issues[login], ", "))
+	}
+	fmt.Printf("[!!] dropped assignees: %s\n", strings.Join(xs, ", "))
+	if m.droppedAssigneesPath == "" {
+		return nil
+	}
+	var report []*droppedAssignee
+	if err := loadReport(m.droppedAssigneesPath, &report); err != nil {
+		return err
+	}
+	for _, d := range m.droppedAssignees {
+		var found bool
+		for _, r := range report {
+			if *r == *d {
+				found = true
+				break
+			}
+		}
+		if !found {
+			report = append(report, d)
+		}
+	}
+	return saveReport(m.droppedAssigneesPath, report)
+}
+
+// assigneeLogins returns the logins of the assignees of the issue.
+func assigneeLogins(issue *github.Issue) []string {
+	users := issue.Assignees
+	if len(users) == 0 && issue.Assignee != nil {
+		users = []*github.User{issue.Assignee}
+	}
+	xs := make([]string, len(users))
+	for i, u := range users {
+		xs[i] = u.Login
+	}
+	return xs
+}
+
+func containsString(xs []string, x string) bool {
+	for _, y := range xs {
+		if x == y {
+			return true
+		}
+	}
+	return false
+}
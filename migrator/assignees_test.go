@@ -0,0 +1,38 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestReportDroppedAssignees(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dropped-assignees.json")
+	report := func(ds ...*droppedAssignee) {
+		m := &migrator{targetRepo: &github.Repo{HTMLURL: "http://localhost/example/target"}}
+		MigratorDroppedAssignees(path)(m)
+		m.droppedAssignees = ds
+		require.NoError(t, m.reportDroppedAssignees())
+	}
+	report(
+		&droppedAssignee{Issue: "http://localhost/example/target/issues/1", Assignee: "sample-user-3"},
+		&droppedAssignee{Issue: "http://localhost/example/target/issues/2", Assignee: "sample-user-3"},
+	)
+	// the resumed run reports the dropped assignees of the existing issues again
+	report(
+		&droppedAssignee{Issue: "http://localhost/example/target/issues/2", Assignee: "sample-user-3"},
+		&droppedAssignee{Issue: "http://localhost/example/target/issues/3", Assignee: "sample-user-4"},
+	)
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"issue": "http://localhost/example/target/issues/1", "assignee": "sample-user-3"},
+  {"issue": "http://localhost/example/target/issues/2", "assignee": "sample-user-3"},
+  {"issue": "http://localhost/example/target/issues/3", "assignee": "sample-user-4"}
+]`, string(bs))
+}
@@ -0,0 +1,196 @@
+package migrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// MigratorAttachments returns a migrator option to migrate the attachments
+// (images and files uploaded to the source host) to the assets repository.
+// The migrated urls are saved to the manifest file, so that the attachments
+// are not uploaded again on the next run. The attachments which cannot be
+// migrated are kept as they are, and written to the report file.
+func MigratorAttachments(assets *repo.Repo, branch, manifestPath, reportPath string) MigratorOption {
+	return func(m *migrator) {
+		m.attachments = &attachments{
+			assets:       assets,
+			branch:       branch,
+			manifestPath: manifestPath,
+			reportPath:   reportPath,
+		}
+	}
+}
+
+type attachments struct {
+	assets       *repo.Repo
+	branch       string
+	manifestPath string
+	assetsRepo   *github.Repo
+	urls         map[string]string
+	reportPath   string
+	location     string
+	report       []*attachmentReport
+	previous     []*attachmentReport
+}
+
+type attachmentReport struct {
+	Location string `json:"location"`
+	URL      string `json:"url"`
+	Error    string `json:"error"`
+}
+
+func (a *attachments) loadManifest() error {
+	a.urls = make(map[string]string)
+	bs, err := os.ReadFile(a.manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := json.Unmarshal(bs, &a.urls); err != nil {
+		return fmt.Errorf("%s: %w", a.manifestPath, err)
+	}
+	return nil
+}
+
+// loadReport loads the report of the previous runs, which has the failed
+// attachments in the issues skipped on this run.
+func (a *attachments) loadReport() error {
+	if a.reportPath == "" {
+		return nil
+	}
+	return loadReport(a.reportPath, &a.previous)
+}
+
+// saveReport writes the report, replacing the entries of the previous runs
+// with the same location and url, and the ones migrated on this run.
+func (a *attachments) saveReport() error {
+	if a.reportPath == "" {
+		return nil
+	}
+	type key struct{ location, url string }
+	keys := make(map[key]bool, len(a.report))
+	for _, x := range a.report {
+		keys[key{x.Location, x.URL}] = true
+	}
+	report := []*attachmentReport{}
+	for _, x := range a.previous {
+		if _, ok := a.urls[x.URL]; !ok && !keys[key{x.Location, x.URL}] {
+			report = append(report, x)
+		}
+	}
+	return saveReport(a.reportPath, append(report, a.report...))
+}
+
+func (a *attachments) reportFailure(link string, err error) {
+	for _, r := range a.report {
+		if r.Location == a.location && r.URL == link {
+			return
+		}
+	}
+	a.report = append(a.report, &attachmentReport{Location: a.location, URL: link, Error: err.Error()})
+}
+
+func (m *migrator) saveAttachmentsReport() error {
+	if m.attachments == nil {
+		return nil
+	}
+	return m.attachments.saveReport()
+}
+
+func (a *attachments) saveManifest() error {
+	bs, err := json.MarshalIndent(a.urls, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(a.manifestPath, append(bs, '\n'), 0644)
+}
+
+var (
+	attachmentURLPattern  = regexp.MustCompile(`https?://[^\s"'<>()\[\]]+[^\s"'<>()\[\].,;:!?]`)
+	attachmentPathPattern = regexp.MustCompile(`^/(?:storage/user/|user-images/|[^/]+/[^/]+/files/)`)
+)
+
+// isAttachmentURL reports whether the url is an attachment uploaded to the
+// source host (or the subdomains for the assets).
+func isAttachmentURL(u *url.URL, host string) bool {
+	if u.Host != host && !strings.HasSuffix(u.Host, "."+host) {
+		return false
+	}
+	return attachmentPathPattern.MatchString(u.Path) || strings.HasPrefix(u.Host, "user-images.")
+}
+
+// newAttachmentFilter creates a filter to replace the attachment urls with the
+// urls in the assets repository. This filter should be applied before the
+// other filters rewrite the urls of the source host.
+func (m *migrator) newAttachmentFilter() (commentFilter, error) {
+	a := m.attachments
+	if err := a.loadManifest(); err != nil {
+		return nil, err
+	}
+	if err := a.loadReport(); err != nil {
+		return nil, err
+	}
+	var err error
+	if a.assetsRepo, err = a.assets.Get(); err != nil {
+		return nil, err
+	}
+	sourceURL, _ := url.Parse(m.sourceRepo.HTMLURL)
+	return commentFilter(func(src string) string {
+		return attachmentURLPattern.ReplaceAllStringFunc(src, func(s string) string {
+			u, err := url.Parse(s)
+			if err != nil || !isAttachmentURL(u, sourceURL.Host) {
+				return s
+			}
+			t, err := m.migrateAttachment(u)
+			if err != nil {
+				fmt.Printf("[!!] migrating an attachment failed: %s (%s)\n", s, err)
+				a.reportFailure(s, err)
+				return s
+			}
+			return t
+		})
+	}), nil
+}
+
+func (m *migrator) migrateAttachment(u *url.URL) (string, error) {
+	a := m.attachments
+	if t, ok := a.urls[u.String()]; ok {
+		return t, nil
+	}
+	fmt.Printf("[=>] migrating an attachment: %s\n", u)
+	content, err := m.source.Download(u.String())
+	if err != nil {
+		return "", err
+	}
+	filePath := path.Join(u.Host, path.Clean(u.Path))
+	fmt.Printf("[>>] uploading an attachment: %s\n", filePath)
+	if _, err := a.assets.CreateFile(filePath, &github.CreateFileParams{
+		Message: "Add " + filePath,
+		Content: content,
+		Branch:  a.branch,
+	}); err != nil {
+		// the file is already uploaded (but the manifest is lost)
+		if !strings.Contains(err.Error(), `"sha" wasn't supplied`) {
+			return "", err
+		}
+	}
+	branch := a.branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+	a.urls[u.String()] = fmt.Sprintf("%s/raw/%s/%s", a.assetsRepo.HTMLURL, github.EscapePath(branch), github.EscapePath(filePath))
+	if err := a.saveManifest(); err != nil {
+		return "", err
+	}
+	return a.urls[u.String()], nil
+}
@@ -0,0 +1,96 @@
+package migrator
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestAttachmentFilter(t *testing.T) {
+	manifestPath := filepath.Join(t.TempDir(), "attachments.json")
+	reportPath := filepath.Join(t.TempDir(), "failed-attachments.json")
+	var downloads []string
+	var files []string
+	source := repo.New(github.NewMockClient(
+		github.MockDownload(func(url string) ([]byte, error) {
+			downloads = append(downloads, url)
+			if url == "http://localhost/storage/user/1/files/missing.png" {
+				return nil, errors.New("Not Found")
+			}
+			return []byte("content"), nil
+		}),
+	), "example/test")
+	assets := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{HTMLURL: "https://github.com/example/assets"}, nil
+		}),
+		github.MockCreateFile(func(repo, path string, params *github.CreateFileParams) (*github.File, error) {
+			assert.Equal(t, "example/assets", repo)
+			assert.Equal(t, "attachments", params.Branch)
+			assert.Equal(t, []byte("content"), params.Content)
+			files = append(files, path)
+			return &github.File{Path: path}, nil
+		}),
+	), "example/assets")
+	migrate := func(src string) string {
+		m := &migrator{source: source, sourceRepo: &github.Repo{HTMLURL: "http://localhost/example/test"}}
+		MigratorAttachments(assets, "attachments", manifestPath, reportPath)(m)
+		f, err := m.newAttachmentFilter()
+		assert.Nil(t, err)
+		m.setLocation("http://localhost/example/test/issues/1")
+		defer func() { assert.Nil(t, m.saveAttachmentsReport()) }()
+		return f(src)
+	}
+
+	src := `![image](http://localhost/storage/user/1/files/abc.png)
+<img src="http://localhost/example/test/files/12/log.txt" width="200">
+http://localhost/example/test/files/12/log.txt.
+http://localhost/storage/user/1/files/missing.png
+http://localhost/storage/user/1/files/my%20log%23%E2%9C%93.txt
+http://localhost/example/test/blob/master/files/README.md
+https://example.com/storage/user/1/files/abc.png`
+	expected := `![image](https://github.com/example/assets/raw/attachments/localhost/storage/user/1/files/abc.png)
+<img src="https://github.com/example/assets/raw/attachments/localhost/example/test/files/12/log.txt" width="200">
+https://github.com/example/assets/raw/attachments/localhost/example/test/files/12/log.txt.
+http://localhost/storage/user/1/files/missing.png
+https://github.com/example/assets/raw/attachments/localhost/storage/user/1/files/my%20log%23%E2%9C%93.txt
+http://localhost/example/test/blob/master/files/README.md
+https://example.com/storage/user/1/files/abc.png`
+	assert.Equal(t, expected, migrate(src))
+	assert.Equal(t, []string{
+		"localhost/storage/user/1/files/abc.png",
+		"localhost/example/test/files/12/log.txt",
+		"localhost/storage/user/1/files/my log#✓.txt",
+	}, files)
+	assert.Len(t, downloads, 4)
+
+	bs, err := os.ReadFile(manifestPath)
+	assert.Nil(t, err)
+	var urls map[string]string
+	assert.Nil(t, json.Unmarshal(bs, &urls))
+	assert.Len(t, urls, 3)
+
+	// the migrated attachments are looked up in the manifest on the next run
+	downloads, files = nil, nil
+	assert.Equal(t, expected, migrate(src))
+	assert.Nil(t, files)
+	assert.Equal(t, []string{"http://localhost/storage/user/1/files/missing.png"}, downloads)
+
+	// the failed attachments are reported
+	bs, err = os.ReadFile(reportPath)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+  {
+    "location": "http://localhost/example/test/issues/1",
+    "url": "http://localhost/storage/user/1/files/missing.png",
+    "error": "Not Found"
+  }
+]`, string(bs))
+}
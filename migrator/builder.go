@@ -2,7 +2,6 @@ package migrator
 
 import (
 	"fmt"
-	"html"
 	"strings"
 	"time"
 
@@ -19,6 +18,7 @@ type builder struct {
 	commitDiff     string
 	reviews        []*github.Review
 	reviewComments []*github.ReviewComment
+	reactions      reactions
 	skipAssignee   bool
 }
 
@@ -27,7 +27,7 @@ func (m *migrator) buildImport(
 	comments []*github.Comment, events []*github.Event,
 	commits []*github.Commit, commitDiff string,
 	reviews []*github.Review, reviewComments []*github.ReviewComment,
-	skipAssignee bool,
+	reactions reactions, skipAssignee bool,
 ) (*github.Import, error) {
 	return (&builder{
 		migrator:       m,
@@ -39,14 +39,19 @@ func (m *migrator) buildImport(
 		commitDiff:     commitDiff,
 		reviews:        reviews,
 		reviewComments: reviewComments,
+		reactions:      reactions,
 		skipAssignee:   skipAssignee,
 	}).build()
 }
 
 func (b *builder) build() (*github.Import, error) {
+	body, err := b.buildImportBody()
+	if err != nil {
+		return nil, err
+	}
 	importIssue := &github.ImportIssue{
-		Title:     b.issue.Title,
-		Body:      b.buildImportBody(),
+		Title:     b.filterTitle(b.issue.Title),
+		Body:      body,
 		CreatedAt: b.issue.CreatedAt,
 		UpdatedAt: b.issue.UpdatedAt,
 		Closed:    b.issue.State != github.IssueStateOpen,
@@ -54,7 +59,7 @@ func (b *builder) build() (*github.Import, error) {
 		Labels:    b.buildImportLabels(b.issue),
 	}
 	if !b.skipAssignee && b.issue.Assignee != nil {
-		target := b.commentFilters.apply(b.issue.Assignee.Login)
+		target := b.mapLogin(b.issue.Assignee.Login)
 		isMember, err := b.isTargetMember(target)
 		if err != nil {
 			return nil, err
@@ -64,7 +69,7 @@ func (b *builder) build() (*github.Import, error) {
 		}
 	}
 	if b.issue.Milestone != nil {
-		if l, ok := b.milestoneByTitle[b.issue.Milestone.Title]; ok {
+		if l, ok := b.milestoneByTitle[b.lookupMilestoneTitle(b.issue.Milestone.Title)]; ok {
 			importIssue.Milestone = l.Number
 		}
 	}
@@ -75,85 +80,107 @@ func (b *builder) build() (*github.Import, error) {
 	return &github.Import{Issue: importIssue, Comments: comments}, nil
 }
 
-func (b *builder) buildImportBody() string {
-	var suffix string
+func (b *builder) buildImportBody() (string, error) {
+	var body string
 	if b.issue.Body != "" {
-		suffix = "\n\n" + b.commentFilters.apply(b.issue.Body)
+		body = b.commentFilters.apply(b.issue.Body)
 	}
-	action := fmt.Sprintf("created the original %s<br>\n", b.issue.Type())
-	if b.pullReq != nil {
-		action += b.buildCompareLinkTag(b.targetRepo, b.pullReq.Base.SHA, b.pullReq.Head.SHA) +
-			" " + b.buildPullRequestRefs() + "<br>\n"
+	data := &issueData{
+		User:        b.buildUserData(b.issue.User),
+		Type:        b.issue.Type().String(),
+		Number:      b.issue.Number,
+		Title:       b.filterTitle(b.issue.Title),
+		CreatedAt:   b.issue.CreatedAt,
+		Timestamp:   b.buildTimestamp(b.issue.CreatedAt),
+		OriginalURL: b.issue.HTMLURL,
+		SourceRepo:  b.sourceRepo.FullName,
+		PullRequest: b.buildPullRequestData(),
+		Body:        body,
+		Reactions:   b.buildReactions(b.reactions.issue),
 	}
-	action += "imported from " + buildIssueLinkTag(b.sourceRepo, b.issue)
-	tableRows := [][]string{
-		{
-			b.buildImageTag(b.issue.User, 35),
-			fmt.Sprintf("@%s %s", b.getUserLogin(b.issue.User), action),
-		},
+	for i, c := range b.commits {
+		if i > 90 && len(b.commits) > 100 {
+			data.MoreCommits = len(b.commits) - i
+			break
+		}
+		data.Commits = append(data.Commits, b.buildCommitData(c))
 	}
-	if len(b.commitDiff) > 0 {
-		tableRows = append(tableRows, []string{b.buildDiffDetails()})
+	return b.render("issue", data)
+}
+
+func (b *builder) buildPullRequestData() *pullRequestData {
+	if b.pullReq == nil {
+		return nil
 	}
-	if len(b.commits) > 0 {
-		tableRows = append(tableRows, []string{b.buildCommitDetails()})
+	base, baseDropped := b.lookupCommitSHA(b.pullReq.Base.SHA)
+	head, headDropped := b.lookupCommitSHA(b.pullReq.Head.SHA)
+	data := &pullRequestData{
+		BaseRef:      b.pullReq.Base.Ref,
+		HeadRef:      b.pullReq.Head.Ref,
+		BaseSHA:      base,
+		HeadSHA:      head,
+		BaseShortSHA: base[:7],
+		HeadShortSHA: head[:7],
+		CompareURL:   fmt.Sprintf("%s/compare/%s...%s", b.targetRepo.HTMLURL, base, head),
+		ChangedFiles: b.pullReq.ChangedFiles,
+		Additions:    b.pullReq.Additions,
+		Deletions:    b.pullReq.Deletions,
+		CommitCount:  b.pullReq.Commits,
+		Dropped:      baseDropped || headDropped,
+	}
+	if len(b.commitDiff) > 0 {
+		data.Diff = escapeBackQuotes(truncateDiff(b.commitDiff))
 	}
-	return b.buildTable(2, tableRows...) + suffix
+	return data
 }
 
-func (b *builder) buildDiffDetails() string {
-	summary := plural(b.pullReq.ChangedFiles, "file") + " changed"
-	if b.pullReq.Additions > 0 {
-		summary += ", " + plural(b.pullReq.Additions, "insertion") + "(+)"
+func (b *builder) buildCommitData(c *github.Commit) *commitData {
+	committer := c.Committer
+	if committer == nil {
+		committer = c.Author
 	}
-	if b.pullReq.Deletions > 0 {
-		summary += ", " + plural(b.pullReq.Deletions, "deletion") + "(-)"
+	if committer == nil {
+		committer = &github.User{Login: c.Commit.Committer.Name}
+	}
+	sha, dropped := b.lookupCommitSHA(c.SHA)
+	return &commitData{
+		User:        b.buildUserData(committer),
+		Message:     b.commentFilters.apply(c.Commit.Message),
+		SHA:         sha,
+		ShortSHA:    sha[:7],
+		URL:         b.targetRepo.HTMLURL + "/commit/" + sha,
+		CommittedAt: c.Commit.Committer.Date,
+		Dropped:     dropped,
 	}
-	return b.buildDetails("  ", summary, "\n```diff\n"+
-		escapeBackQuotes(truncateDiff(b.commitDiff))+
-		"```\n")
 }
 
-func (b *builder) buildCommitDetails() string {
-	summary := plural(b.pullReq.Commits, "commit")
-	var commitRows [][]string
-	for i, c := range b.commits {
-		if i > 90 && len(b.commits) > 100 {
-			commitRows = append(commitRows, []string{
-				fmt.Sprintf("more %d commits", len(b.commits)-i),
-			})
-			break
-		}
-		var dateString string
-		committer := c.Committer
-		if committer == nil {
-			committer = c.Author
-		}
-		if committer == nil {
-			committer = &github.User{Login: c.Commit.Committer.Name}
-		}
-		t, err := time.Parse(time.RFC3339, c.Commit.Committer.Date)
-		if err == nil {
-			dateString = t.Format(" on Mon 2, 2006")
-		}
-		commitRows = append(commitRows, []string{
-			html.EscapeString(c.Commit.Message) + "<br>\n" +
-				b.buildImageTag(committer, 16) +
-				fmt.Sprintf(" @%s committed%s", b.commentFilters.apply(committer.Login), dateString) +
-				fmt.Sprintf(` <a href="%s">%s</a>`, b.commentFilters.apply(c.HTMLURL), c.SHA[:7]),
-		})
+// lookupCommitSHA returns the SHA of the commit on the target, or the source
+// SHA when the commit was dropped.
+func (b *builder) lookupCommitSHA(sha string) (string, bool) {
+	newSHA, dropped := b.lookupCommit(sha)
+	if dropped {
+		return sha, true
 	}
-	return b.buildDetails("", summary, b.buildTable(1, commitRows...))
+	return newSHA, false
 }
 
 func (b *builder) buildImportComments() ([]*github.ImportComment, error) {
-	issueComments := b.buildImportIssueComments()
+	issueComments, err := b.buildImportIssueComments()
+	if err != nil {
+		return nil, err
+	}
 	eventComments, err := b.buildImportEventComments()
 	if err != nil {
 		return nil, err
 	}
-	reviewComments := b.buildImportReviewComments()
-	importReviews := b.buildImportReviews()
+	reviewComments, err := b.buildImportReviewComments()
+	if err != nil {
+		return nil, err
+	}
+	importReviews, err := b.buildImportReviews()
+	if err != nil {
+		return nil, err
+	}
 	return append(
 		append(
 			append(
@@ -166,18 +193,22 @@ func (b *builder) buildImportComments() ([]*github.ImportComment, error) {
 	), nil
 }
 
-func (b *builder) buildImportIssueComments() []*github.ImportComment {
+func (b *builder) buildImportIssueComments() ([]*github.ImportComment, error) {
 	xs := make([]*github.ImportComment, len(b.comments))
 	for i, c := range b.comments {
+		body, err := b.buildUserActionBody(c.User, "commented", c.CreatedAt, c.Body, b.reactions.comments[c.ID])
+		if err != nil {
+			return nil, err
+		}
 		xs[i] = &github.ImportComment{
-			Body:      b.buildUserActionBody(c.User, "commented", c.Body),
+			Body:      body,
 			CreatedAt: c.CreatedAt,
 		}
 	}
-	return xs
+	return xs, nil
 }
 
-func (b *builder) buildImportReviews() []*github.ImportComment {
+func (b *builder) buildImportReviews() ([]*github.ImportComment, error) {
 	var xs []*github.ImportComment
 	for _, c := range b.reviews {
 		var action string
@@ -190,65 +221,117 @@ func (b *builder) buildImportReviews() []*github.ImportComment {
 		} else {
 			continue
 		}
+		body, err := b.buildUserActionBody(c.User, action, c.SubmittedAt, c.Body, nil)
+		if err != nil {
+			return nil, err
+		}
 		xs = append(xs, &github.ImportComment{
-			Body:      b.buildUserActionBody(c.User, action, c.Body),
+			Body:      body,
 			CreatedAt: c.SubmittedAt,
 		})
 	}
-	return xs
+	return xs, nil
 }
 
-func (b *builder) buildImportReviewComments() []*github.ImportComment {
+func (b *builder) buildImportReviewComments() ([]*github.ImportComment, error) {
 	var xs []*github.ImportComment
 	indexByID := make(map[int]int)
 	for _, c := range b.reviewComments {
+		body, err := b.buildUserActionBody(c.User, "commented", c.CreatedAt, c.Body, b.reactions.reviewComments[c.ID])
+		if err != nil {
+			return nil, err
+		}
 		if i, ok := indexByID[c.InReplyToID]; ok {
 			indexByID[c.ID] = i
-			xs[i].Body += "\n\n" + b.buildUserActionBody(c.User, "commented", c.Body)
+			xs[i].Body += "\n\n" + body
 			continue
 		}
 		indexByID[c.ID] = len(xs)
-		diffBody := strings.Join([]string{"```diff", "# " + c.Path, c.DiffHunk, "```"}, "\n")
+		diffBody := strings.Join([]string{"```diff", "# " + c.Path, b.redact(c.DiffHunk), "```"}, "\n")
 		xs = append(xs, &github.ImportComment{
-			Body:      diffBody + "\n\n" + b.buildUserActionBody(c.User, "commented", c.Body),
+			Body:      diffBody + "\n\n" + body,
 			CreatedAt: c.CreatedAt,
 		})
 	}
-	return xs
+	return xs, nil
 }
 
-func (b *builder) buildPullRequestRefs() string {
-	return fmt.Sprintf(
-		"into <code>%s</code> from <code>%s</code>",
-		html.EscapeString(b.pullReq.Base.Ref),
-		html.EscapeString(b.pullReq.Head.Ref),
-	)
+func (b *builder) buildUserActionBody(
+	user *github.User, action, createdAt, body string, reactions []*github.Reaction,
+) (string, error) {
+	if body != "" {
+		body = b.commentFilters.apply(body)
+	}
+	return b.render("comment", &commentData{
+		User:      b.buildUserData(user),
+		Action:    action,
+		CreatedAt: createdAt,
+		Timestamp: b.buildTimestamp(createdAt),
+		Body:      body,
+		Reactions: b.buildReactions(reactions),
+	})
 }
 
-func (b *builder) buildUserActionBody(user *github.User, action, body string) string {
-	var suffix string
-	if body != "" {
-		suffix = "\n\n" + b.commentFilters.apply(body)
+// buildTimestamp renders the original time only when the creation dates
+// cannot be imported (when the issues are created with the Issues API, or
+// written to the archive).
+func (b *builder) buildTimestamp(createdAt string) string {
+	if !b.renderTimestamps {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(" on Jan 2, 2006, 15:04 MST")
+}
+
+func (b *builder) buildUserData(user *github.User) *userData {
+	login := b.getUserLogin(user)
+	avatar := login
+	if !b.isAvailableUser(avatar) {
+		avatar = "github"
+	}
+	return &userData{
+		Login:     login,
+		AvatarURL: fmt.Sprintf("https://github.com/%s.png", avatar),
 	}
-	return b.buildTable(2, []string{
-		b.buildImageTag(user, 35),
-		fmt.Sprintf("@%s %s", b.getUserLogin(user), action),
-	}) + suffix
 }
 
-func (b *builder) buildImageTag(user *github.User, width int) string {
-	target := b.getUserLogin(user)
-	if !b.isAvailableUser(target) {
-		target = "github"
+// buildTableRow builds a row of the table, skipping the empty cells.
+func buildTableRow(xs ...string) []string {
+	var ys []string
+	for _, x := range xs {
+		if x != "" {
+			ys = append(ys, x)
+		}
 	}
-	return fmt.Sprintf(`<img src="https://github.com/%s.png" width="%d">`, target, width)
+	return ys
 }
 
-func (b *builder) buildTable(width int, xss ...[]string) string {
+// buildTable builds the table of the rows, each of which is a row
+// ([]string) or rows ([][]string). The empty rows are skipped.
+func buildTable(width int, rows ...interface{}) (string, error) {
+	var xss [][]string
+	for _, row := range rows {
+		switch row := row.(type) {
+		case []string:
+			xss = append(xss, row)
+		case [][]string:
+			xss = append(xss, row...)
+		case nil:
+		default:
+			return "", fmt.Errorf("table: unexpected row: %T", row)
+		}
+	}
 	s := new(strings.Builder)
 	s.WriteString("<table>\n")
-	for i, xs := range xss {
-		if i > 0 {
+	var i int
+	for _, xs := range xss {
+		if len(xs) == 0 {
+			continue
+		}
+		if i++; i > 1 {
 			s.WriteString("<tr></tr>\n")
 		}
 		s.WriteString("<tr>\n")
@@ -270,10 +353,10 @@ func (b *builder) buildTable(width int, xss ...[]string) string {
 		s.WriteString("</tr>\n")
 	}
 	s.WriteString("</table>\n")
-	return s.String()
+	return s.String(), nil
 }
 
-func (b *builder) buildDetails(indent, summary, details string) string {
+func buildDetails(indent, summary, details string) string {
 	s := new(strings.Builder)
 	s.WriteString(indent + "<details>\n")
 	s.WriteString(fmt.Sprintf(indent+"  <summary>%s</summary>\n", summary))
@@ -296,24 +379,15 @@ func makeIndent(indent, str string) string {
 	return strings.Join(xs, "\n")
 }
 
-func buildIssueLinkTag(repo *github.Repo, issue *github.Issue) string {
-	return fmt.Sprintf(`<a href="%s">%s#%d</a>`, issue.HTMLURL, repo.FullName, issue.Number)
-}
-
-func (b *builder) buildCommitLinkTag(repo *github.Repo, sha string) string {
-	return fmt.Sprintf(`<a href="%s/commit/%s">%s</a>`, repo.HTMLURL, sha, sha[:7])
-}
-
-func (b *builder) buildCompareLinkTag(repo *github.Repo, base, head string) string {
-	return fmt.Sprintf(`<a href="%s/compare/%s...%s">%s...%s</a>`, repo.HTMLURL, base, head, base[:7], head[:7])
-}
-
 func (b *builder) buildImportLabels(issue *github.Issue) []string {
 	xs := []string{}
 	for _, l := range issue.Labels {
 		xs = append(xs, l.Name)
 	}
-	return xs
+	if b.sourceLabel != "" {
+		xs = append(xs, b.sourceLabel)
+	}
+	return b.mapLabels(xs)
 }
 
 func (b *builder) isAvailableUser(name string) bool {
@@ -328,5 +402,5 @@ func (b *builder) getUserLogin(user *github.User) string {
 	if user == nil {
 		return "ghost"
 	}
-	return b.commentFilters.apply(user.Login)
+	return b.mapLogin(user.Login)
 }
@@ -1,8 +1,6 @@
 package migrator
 
 import (
-	"fmt"
-	"html"
 	"math"
 	"strings"
 	"time"
@@ -19,8 +17,12 @@ func (b *builder) buildImportEventComments() ([]*github.ImportComment, error) {
 			return nil, err
 		}
 		if body != "" {
+			body, err := b.buildUserActionBody(getEventUser(eg[0]), body, eg[0].CreatedAt, "", nil)
+			if err != nil {
+				return nil, err
+			}
 			xs = append(xs, &github.ImportComment{
-				Body:      b.buildUserActionBody(getEventUser(eg[0]), body, ""),
+				Body:      body,
 				CreatedAt: eg[0].CreatedAt,
 			})
 		}
@@ -111,78 +113,42 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 	var removedLabels []string
 
 	for _, e := range eg {
+		name := "event_" + e.Event
+		data := &eventData{
+			Event:       e.Event,
+			Type:        b.issue.Type().String(),
+			PullRequest: b.buildPullRequestData(),
+		}
 		switch e.Event {
 		case "closed":
-			if !merged {
-				if b.pullReq == nil {
-					actions = append(actions, "closed the issue")
-				} else {
-					actions = append(actions, "closed the pull request without merging")
-				}
+			if merged {
+				continue
 			}
 		case "merged":
 			merged = true
-			actions = append(actions,
-				fmt.Sprintf(
-					"merged the pull request<br>\ncommit %s ",
-					b.buildCommitLinkTag(b.targetRepo, e.CommitID),
-				)+b.buildPullRequestRefs(),
-			)
+			sha, dropped := b.lookupCommitSHA(e.CommitID)
+			data.CommitURL = b.targetRepo.HTMLURL + "/commit/" + sha
+			data.ShortSHA = sha[:7]
+			data.Dropped = dropped
 		case "reopened":
-			actions = append(actions, fmt.Sprintf("reopened the %s", b.issue.Type()))
 		case "labeled":
 			addedLabels = append(addedLabels, e.Label.Name)
+			continue
 		case "unlabeled":
 			removedLabels = append(removedLabels, e.Label.Name)
+			continue
 		case "renamed":
-			actions = append(actions,
-				fmt.Sprintf(
-					"changed the title <b><s>%s</s></b> <b>%s</b>",
-					html.EscapeString(e.Rename.From), html.EscapeString(e.Rename.To),
-				),
-			)
-		case "head_ref_deleted":
-			actions = append(actions,
-				fmt.Sprintf(
-					"deleted the <code>%s</code> branch",
-					html.EscapeString(b.pullReq.Head.Ref),
-				),
-			)
-		case "head_ref_restored":
-			actions = append(actions,
-				fmt.Sprintf(
-					"restored the <code>%s</code> branch",
-					html.EscapeString(b.pullReq.Head.Ref),
-				),
-			)
-		case "head_ref_force_pushed", "base_ref_force_pushed":
-			ref := b.pullReq.Head.Ref
-			if e.Event == "base_ref_force_pushed" {
-				ref = b.pullReq.Base.Ref
-			}
-			actions = append(actions,
-				fmt.Sprintf(
-					"force-pushed the <code>%s</code> branch",
-					html.EscapeString(ref),
-				),
-			)
+			data.From, data.To = b.filterTitle(e.Rename.From), b.filterTitle(e.Rename.To)
+		case "head_ref_deleted", "head_ref_restored", "head_ref_force_pushed":
+			data.Ref = b.pullReq.Head.Ref
+		case "base_ref_force_pushed":
+			data.Ref = b.pullReq.Base.Ref
 		case "locked":
-			actions = append(actions,
-				fmt.Sprintf(
-					"locked as <b>%s</b> and limited conversation to collaborators",
-					html.EscapeString(strings.ReplaceAll(e.LockReason, "-", " ")),
-				),
-			)
-		case "unlocked":
-			actions = append(actions, "unlocked this conversation")
-		case "pinned", "unpinned":
-			actions = append(actions, e.Event+` this issue`)
+			data.LockReason = strings.ReplaceAll(e.LockReason, "-", " ")
+		case "unlocked", "pinned", "unpinned":
 		case "assigned", "unassigned":
 			if len(eg) == 1 && len(e.Assignees) <= 1 && e.Assigner.Login == e.Assignee.Login {
-				if e.Event == "assigned" {
-					return "self-assigned this", nil
-				}
-				return "removed their assignment", nil
+				return b.render("event_self_"+e.Event, data)
 			}
 			var targets []*github.User
 			if len(e.Assignees) > 0 {
@@ -190,29 +156,14 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 			} else {
 				targets = append(targets, e.Assignee)
 			}
-			actions = append(actions, e.Event+" "+b.mentionAll(targets))
+			data.Users = b.buildLogins(targets)
 		case "review_requested", "review_request_removed":
-			var actionStr string
-			if e.Event == "review_requested" {
-				actionStr = "requested a review"
-			} else {
-				actionStr = "removed the request for review"
-			}
 			if e.RequestedTeam != nil {
-				actions = append(actions,
-					fmt.Sprintf(
-						`%s from <b>%s</b>`,
-						actionStr,
-						b.commentFilters.apply(e.RequestedTeam.Name),
-					),
-				)
+				data.Team = b.buildTeamName(e.RequestedTeam)
 				break
 			}
 			if len(eg) == 1 && len(e.Reviewers) <= 1 && e.Actor.Login == e.Reviewer.Login {
-				if e.Event == "review_requested" {
-					return "self-requested a review", nil
-				}
-				return "removed their request for review", nil
+				return b.render("event_self_"+e.Event, data)
 			}
 			var targets []*github.User
 			if len(e.Reviewers) > 0 {
@@ -220,161 +171,67 @@ func (b *builder) buildImportEventGroupBody(eg []*github.Event) (string, error)
 			} else {
 				targets = append(targets, e.Reviewer)
 			}
-			actions = append(actions, actionStr+" from "+b.mentionAll(targets))
+			data.Users = b.buildLogins(targets)
 		case "review_dismissed":
-			var target *github.User
 			for _, r := range b.reviews {
 				if r.ID == e.DismissedReview.ReviewID {
-					target = r.User
+					if r.User != nil {
+						data.Reviewer = b.mapLogin(r.User.Login)
+					}
 					break
 				}
 			}
-			if target != nil {
-				actions = append(actions,
-					fmt.Sprintf(
-						`dismissed @%s's review<br>%s`,
-						b.commentFilters.apply(target.Login),
-						html.EscapeString(e.DismissedReview.DismissalMessage),
-					),
-				)
-			} else {
-				actions = append(actions,
-					fmt.Sprintf(
-						`dismissed a review<br>%s`,
-						html.EscapeString(e.DismissedReview.DismissalMessage),
-					),
-				)
-			}
-		case "ready_for_review":
-			actions = append(actions, "marked this pull request as ready for review")
-		case "convert_to_draft":
-			actions = append(actions, "marked this pull request as draft")
-		case "converted_note_to_issue":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
-			if err != nil {
-				return "", err
-			}
-			actions = append(actions,
-				fmt.Sprintf(
-					`created this issue from a note in <b><a href="%s">%s</a></b> (<code>%s</code>)`,
-					b.lookupMigratedProject(p).HTMLURL, html.EscapeString(p.Name),
-					html.EscapeString(e.ProjectCard.ColumnName),
-				),
-			)
-		case "added_to_project":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
-			if err != nil {
-				return "", err
-			}
-			actions = append(actions,
-				fmt.Sprintf(
-					`added this to <code>%s</code> in <b><a href="%s">%s</a></b>`,
-					html.EscapeString(e.ProjectCard.ColumnName),
-					b.lookupMigratedProject(p).HTMLURL, html.EscapeString(p.Name),
-				),
-			)
-		case "moved_columns_in_project":
+			data.Message = b.filterTitle(e.DismissedReview.DismissalMessage)
+		case "ready_for_review", "convert_to_draft":
+		case "converted_note_to_issue", "added_to_project",
+			"moved_columns_in_project", "removed_from_project":
 			p, err := b.getProject(e.ProjectCard.ProjectID)
 			if err != nil {
 				return "", err
 			}
-			actions = append(actions,
-				fmt.Sprintf(
-					`moved this from <code>%s</code> to <code>%s</code> in <b><a href="%s">%s</a></b>`,
-					html.EscapeString(e.ProjectCard.PreviousColumnName),
-					html.EscapeString(e.ProjectCard.ColumnName),
-					b.lookupMigratedProject(p).HTMLURL, html.EscapeString(p.Name),
-				),
-			)
-		case "removed_from_project":
-			p, err := b.getProject(e.ProjectCard.ProjectID)
-			if err != nil {
-				return "", err
+			data.Project = &projectData{
+				Name: p.Name,
+				URL:  b.lookupMigratedProject(p).HTMLURL,
 			}
-			actions = append(actions,
-				fmt.Sprintf(
-					`removed this from <code>%s</code> in <b><a href="%s">%s</a></b>`,
-					html.EscapeString(e.ProjectCard.ColumnName),
-					b.lookupMigratedProject(p).HTMLURL, html.EscapeString(p.Name),
-				),
-			)
+			data.Column = e.ProjectCard.ColumnName
+			data.PreviousColumn = e.ProjectCard.PreviousColumnName
 		case "milestoned", "demilestoned":
-			var actionStr string
-			if e.Event == "milestoned" {
-				actionStr = "added this to"
-			} else {
-				actionStr = "removed this from"
-			}
-			if m := b.milestoneByTitle[e.Milestone.Title]; m != nil {
-				actions = append(actions,
-					fmt.Sprintf(
-						`%s the <b><a href="%s">%s</a></b> milestone`,
-						actionStr,
-						m.HTMLURL,
-						html.EscapeString(e.Milestone.Title),
-					),
-				)
-			} else {
-				actions = append(actions,
-					fmt.Sprintf(
-						`%s the <b>%s</b> milestone`,
-						actionStr,
-						html.EscapeString(e.Milestone.Title),
-					),
-				)
+			data.Milestone = &milestoneData{Title: b.lookupMilestoneTitle(e.Milestone.Title)}
+			if m := b.milestoneByTitle[data.Milestone.Title]; m != nil {
+				data.Milestone.URL = m.HTMLURL
 			}
 		case "deployed":
-			actions = append(actions, `deployed this`)
+		default:
+			continue
+		}
+		action, err := b.render(name, data)
+		if err != nil {
+			return "", err
 		}
+		actions = append(actions, action)
 	}
 
-	var action string
 	if len(actions) > 0 {
-		for i, a := range actions {
-			if i > 0 {
-				if i == len(actions)-1 {
-					action += " and "
-				} else {
-					action += ", "
-				}
-			}
-			action += a
-		}
-		return action, nil
+		return b.render("events", &eventsData{Actions: actions, Last: len(actions) - 1})
 	}
 
-	if len(addedLabels) > 0 {
-		action += "added " + quoteLabels(addedLabels)
-	}
-	if len(removedLabels) > 0 {
-		if action != "" {
-			action += " and "
-		}
-		action += "removed " + quoteLabels(removedLabels)
-	}
+	addedLabels, removedLabels = b.mapLabels(addedLabels), b.mapLabels(removedLabels)
 	if len(addedLabels) > 0 || len(removedLabels) > 0 {
-		action += pluralUnit(len(addedLabels)+len(removedLabels), " label")
+		return b.render("event_labels", &eventData{
+			Added:   addedLabels,
+			Removed: removedLabels,
+			Count:   len(addedLabels) + len(removedLabels),
+		})
 	}
-	return action, nil
+	return "", nil
 }
 
-func (b *builder) mentionAll(users []*github.User) string {
-	var s string
+func (b *builder) buildLogins(users []*github.User) []string {
+	xs := make([]string, len(users))
 	for i, u := range users {
-		if i > 0 {
-			s += " "
-		}
-		s += "@" + b.commentFilters.apply(u.Login)
-	}
-	return s
-}
-
-func quoteLabels(xs []string) string {
-	ys := make([]string, len(xs))
-	for i, x := range xs {
-		ys[i] = "<b><code>" + html.EscapeString(x) + "</code></b>"
+		xs[i] = b.mapLogin(u.Login)
 	}
-	return strings.Join(ys, " ")
+	return xs
 }
 
 func (b *builder) lookupMigratedProject(orig *github.Project) *github.Project {
@@ -53,7 +53,7 @@ func newUserMappingFilter(userMapping map[string]string, targetRepo *github.Repo
 			return userMapping[from]
 		})
 		src = re3.ReplaceAllStringFunc(src, func(url string) string {
-			if strings.Contains(url, "://"+targetURL.Host+"/") {
+			if strings.Contains(url, "://"+targetURL.Host+"/") || strings.Contains(url, "."+targetURL.Host+"/") {
 				return url
 			}
 			return re2.ReplaceAllStringFunc(url, func(to string) string {
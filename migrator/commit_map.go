@@ -0,0 +1,133 @@
+package migrator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CommitMap maps the commit SHAs of the source to the ones of the target,
+// for the repositories whose history was rewritten (by git-filter-repo for
+// example) before pushing to the target. The dropped commits are mapped to
+// the empty string.
+type CommitMap struct {
+	commits map[string]string
+	shas    []string
+}
+
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// LoadCommitMap loads the commit map file in the format of the commit-map of
+// git-filter-repo; each line has the old and the new SHAs separated by the
+// spaces, the header line (old new) is skipped, and the new SHA of the
+// dropped commit is all zeros.
+func LoadCommitMap(path string) (*CommitMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	cm := &CommitMap{commits: make(map[string]string)}
+	s := bufio.NewScanner(f)
+	for i := 1; s.Scan(); i++ {
+		xs := strings.Fields(s.Text())
+		if len(xs) == 0 || i == 1 && len(xs) == 2 && xs[0] == "old" && xs[1] == "new" {
+			continue
+		}
+		if len(xs) != 2 || !commitSHAPattern.MatchString(xs[0]) || !commitSHAPattern.MatchString(xs[1]) {
+			return nil, fmt.Errorf("%s:%d: invalid line: %q", path, i, s.Text())
+		}
+		if strings.Trim(xs[1], "0") == "" {
+			xs[1] = ""
+		}
+		cm.commits[xs[0]] = xs[1]
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	cm.shas = make([]string, 0, len(cm.commits))
+	for sha := range cm.commits {
+		cm.shas = append(cm.shas, sha)
+	}
+	sort.Strings(cm.shas)
+	return cm, nil
+}
+
+// Lookup returns the SHA on the target of the full or abbreviated (at least 7
+// characters) SHA, abbreviated to the same length. The new SHA is empty when
+// the commit was dropped, and ok is false when the SHA is not in the map (or
+// the abbreviated SHA is ambiguous).
+func (cm *CommitMap) Lookup(sha string) (newSHA string, ok bool) {
+	if len(sha) < 7 || len(sha) > 40 {
+		return "", false
+	}
+	sha = strings.ToLower(sha)
+	if len(sha) < 40 {
+		i := sort.SearchStrings(cm.shas, sha)
+		if i == len(cm.shas) || !strings.HasPrefix(cm.shas[i], sha) ||
+			i+1 < len(cm.shas) && strings.HasPrefix(cm.shas[i+1], sha) {
+			return "", false
+		}
+		newSHA = cm.commits[cm.shas[i]]
+	} else if newSHA, ok = cm.commits[sha]; !ok {
+		return "", false
+	}
+	if newSHA == "" {
+		return "", true
+	}
+	return newSHA[:len(sha)], true
+}
+
+// MigratorCommitMap returns a migrator option to rewrite the commit SHAs in
+// the links and the text with the commit map.
+func MigratorCommitMap(cm *CommitMap) MigratorOption {
+	return func(m *migrator) {
+		m.commitMap = cm
+	}
+}
+
+// lookupCommit returns the SHA of the commit on the target, and whether the
+// commit was dropped from the history.
+func (m *migrator) lookupCommit(sha string) (string, bool) {
+	if m.commitMap == nil {
+		return sha, false
+	}
+	newSHA, ok := m.commitMap.Lookup(sha)
+	if !ok {
+		return sha, false
+	}
+	return newSHA, newSHA == ""
+}
+
+// newCommitMapFilter creates a filter to rewrite the commit SHAs in the text
+// (including the commit and compare URLs). The short SHAs (less than 10
+// characters) are rewritten only in the commit and compare URLs and the code
+// spans, so that the words like deadbeef are kept. The bare SHAs of the
+// dropped commits are kept and marked, but the ones in the URLs are kept as
+// they are.
+func (m *migrator) newCommitMapFilter() commentFilter {
+	pattern := regexp.MustCompile("(/commit/|/compare/|\\.\\.\\.?|`|^|[^\\w])([0-9a-fA-F]{7,40})\\b(`?)")
+	return commentFilter(func(src string) string {
+		return pattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := pattern.FindStringSubmatch(s)
+			if strings.Trim(xs[2], "0123456789") == "" {
+				return s // skip the numbers
+			}
+			inURL := strings.HasPrefix(xs[1], "/") || strings.HasPrefix(xs[1], ".")
+			if len(xs[2]) < 10 && !(inURL && len(xs[1]) > 1) && !(xs[1] == "`" && xs[3] == "`") {
+				return s
+			}
+			sha, dropped := m.lookupCommit(xs[2])
+			if dropped {
+				if inURL {
+					return s
+				}
+				return s + " (dropped commit)"
+			}
+			return xs[1] + sha + xs[3]
+		})
+	})
+}
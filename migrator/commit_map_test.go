@@ -0,0 +1,95 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func writeTestCommitMap(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "commit-map")
+	require.NoError(t, os.WriteFile(path, []byte(`old                                      new
+1234567890abcdef1234567890abcdef12345678 abcdefabcdefabcdefabcdefabcdefabcdefabcd
+1234567aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb
+deadbeefdeadbeefdeadbeefdeadbeefdeadbeef 0000000000000000000000000000000000000000
+`), 0o644))
+	return path
+}
+
+func TestLoadCommitMap(t *testing.T) {
+	cm, err := LoadCommitMap(writeTestCommitMap(t))
+	require.NoError(t, err)
+	for _, tc := range []struct {
+		sha, expected string
+		ok            bool
+	}{
+		{"1234567890abcdef1234567890abcdef12345678", "abcdefabcdefabcdefabcdefabcdefabcdefabcd", true},
+		{"1234567890", "abcdefabcd", true},
+		{"1234567AAA", "bbbbbbbbbb", true},
+		{"1234567", "", false},
+		{"123456", "", false},
+		{"deadbeef", "", true},
+		{"0123456789", "", false},
+	} {
+		sha, ok := cm.Lookup(tc.sha)
+		assert.Equal(t, tc.expected, sha, tc.sha)
+		assert.Equal(t, tc.ok, ok, tc.sha)
+	}
+
+	path := filepath.Join(t.TempDir(), "commit-map")
+	require.NoError(t, os.WriteFile(path, []byte("old new\n1234567 abcdefg\n"), 0o644))
+	_, err = LoadCommitMap(path)
+	assert.EqualError(t, err, path+`:2: invalid line: "1234567 abcdefg"`)
+}
+
+func TestCommitMapFilter(t *testing.T) {
+	cm, err := LoadCommitMap(writeTestCommitMap(t))
+	require.NoError(t, err)
+	m := &migrator{commitMap: cm}
+	filter := m.newCommitMapFilter()
+	for _, tc := range []struct {
+		src, expected string
+	}{
+		{"Fixed in 1234567890abc.", "Fixed in abcdefabcdefa."},
+		{
+			"http://localhost/example/source/compare/1234567890a...1234567aa",
+			"http://localhost/example/source/compare/abcdefabcde...bbbbbbbbb",
+		},
+		{"Reverts `deadbeef` (1234567).", "Reverts `deadbeef` (dropped commit) (1234567)."},
+		{"Reverts deadbeefdeadbeef.", "Reverts deadbeefdeadbeef (dropped commit)."},
+		{"The deadbeef and 1234567aaa colors, #1234567.", "The deadbeef and bbbbbbbbbb colors, #1234567."},
+		{"`1234567aa` and http://localhost/example/source/commit/1234567a", "`bbbbbbbbb` and http://localhost/example/source/commit/bbbbbbbb"},
+		{
+			"http://localhost/example/source/commit/deadbeefdeadbeef 12345678901",
+			"http://localhost/example/source/commit/deadbeefdeadbeef 12345678901",
+		},
+	} {
+		assert.Equal(t, tc.expected, filter(tc.src))
+	}
+}
+
+func TestBuildCommitDataCommitMap(t *testing.T) {
+	cm, err := LoadCommitMap(writeTestCommitMap(t))
+	require.NoError(t, err)
+	m := &migrator{commitMap: cm, targetRepo: &github.Repo{HTMLURL: "http://localhost/example/target"}}
+	m.commentFilters = newCommentFilters(m.newCommitMapFilter())
+	b := &builder{migrator: m}
+	commit := func(sha string) *github.Commit {
+		c := &github.Commit{SHA: sha, HTMLURL: "http://localhost/example/source/commit/" + sha}
+		c.Commit.Committer = &github.CommitUser{Name: "ghost"}
+		return c
+	}
+	data := b.buildCommitData(commit("1234567890abcdef1234567890abcdef12345678"))
+	assert.Equal(t, "abcdefa", data.ShortSHA)
+	assert.True(t, strings.HasSuffix(data.URL, "/commit/abcdefabcdefabcdefabcdefabcdefabcdefabcd"))
+	assert.False(t, data.Dropped)
+	data = b.buildCommitData(commit("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"))
+	assert.Equal(t, "deadbee", data.ShortSHA)
+	assert.True(t, data.Dropped)
+}
@@ -0,0 +1,116 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConflictPolicy represents how to resolve the conflicts of the entities which
+// already exist on the target with different fields.
+type ConflictPolicy int
+
+// ConflictPolicy constants.
+const (
+	ConflictPolicySourceWins ConflictPolicy = iota
+	ConflictPolicyTargetWins
+	ConflictPolicyMergeMissingFields
+	ConflictPolicyFail
+)
+
+// ParseConflictPolicy parses the conflict policy.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch s {
+	case "", "source-wins":
+		return ConflictPolicySourceWins, nil
+	case "target-wins":
+		return ConflictPolicyTargetWins, nil
+	case "merge-missing-fields":
+		return ConflictPolicyMergeMissingFields, nil
+	case "fail":
+		return ConflictPolicyFail, nil
+	default:
+		return 0, fmt.Errorf("unknown conflict policy: %s (specify source-wins, target-wins, merge-missing-fields or fail)", s)
+	}
+}
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictPolicyTargetWins:
+		return "target-wins"
+	case ConflictPolicyMergeMissingFields:
+		return "merge-missing-fields"
+	case ConflictPolicyFail:
+		return "fail"
+	default:
+		return "source-wins"
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *ConflictPolicy) UnmarshalText(text []byte) (err error) {
+	*p, err = ParseConflictPolicy(string(text))
+	return
+}
+
+// ConflictPolicies is the conflict policies of the entities.
+type ConflictPolicies struct {
+	Labels     ConflictPolicy
+	Milestones ConflictPolicy
+	Projects   ConflictPolicy
+	Hooks      ConflictPolicy
+}
+
+// MigratorConflictPolicies returns a migrator option to resolve the conflicts
+// of the labels, milestones, projects and hooks which already exist on the
+// target, instead of overwriting them with the source.
+func MigratorConflictPolicies(policies ConflictPolicies) MigratorOption {
+	return func(m *migrator) {
+		m.conflictPolicies = policies
+	}
+}
+
+// conflict is the fields of the entity which differ between the source and
+// the target.
+type conflict struct {
+	diffs, missing []string
+}
+
+// add adds the field if it differs, and missing reports whether the field is
+// empty on the target.
+func (c *conflict) add(field string, differs, missing bool) {
+	if differs {
+		c.diffs = append(c.diffs, field)
+		if missing {
+			c.missing = append(c.missing, field)
+		}
+	}
+}
+
+// resolve decides which fields of the existing entity on the target are
+// updated with the source. The decision is shown in the output.
+func (c *conflict) resolve(policy ConflictPolicy, kind, name string) (map[string]bool, error) {
+	if len(c.diffs) == 0 {
+		fmt.Printf("[--] skipping: %s (already exists)\n", name)
+		return nil, nil
+	}
+	var fields []string
+	switch policy {
+	case ConflictPolicySourceWins:
+		fields = c.diffs
+	case ConflictPolicyMergeMissingFields:
+		fields = c.missing
+	case ConflictPolicyFail:
+		return nil, fmt.Errorf("%s already exists on the target with different %s: %s (conflict policy: %s)",
+			kind, strings.Join(c.diffs, ", "), name, policy)
+	}
+	if len(fields) == 0 {
+		fmt.Printf("[--] skipping: %s (%s: keeping %s of the target)\n", name, policy, strings.Join(c.diffs, ", "))
+		return nil, nil
+	}
+	fmt.Printf("[|>] updating an existing %s: %s (%s: updating %s)\n", kind, name, policy, strings.Join(fields, ", "))
+	update := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		update[field] = true
+	}
+	return update, nil
+}
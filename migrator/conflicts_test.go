@@ -0,0 +1,43 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConflictPolicy(t *testing.T) {
+	for _, s := range []string{"source-wins", "target-wins", "merge-missing-fields", "fail"} {
+		p, err := ParseConflictPolicy(s)
+		assert.Nil(t, err)
+		assert.Equal(t, s, p.String())
+	}
+	_, err := ParseConflictPolicy("skip")
+	assert.EqualError(t, err, "unknown conflict policy: skip (specify source-wins, target-wins, merge-missing-fields or fail)")
+}
+
+func TestConflictResolve(t *testing.T) {
+	var c conflict
+	c.add("description", true, true)
+	c.add("color", true, false)
+	c.add("state", false, false)
+
+	update, err := c.resolve(ConflictPolicySourceWins, "label", "bug")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"description": true, "color": true}, update)
+
+	update, err = c.resolve(ConflictPolicyTargetWins, "label", "bug")
+	assert.Nil(t, err)
+	assert.Nil(t, update)
+
+	update, err = c.resolve(ConflictPolicyMergeMissingFields, "label", "bug")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"description": true}, update)
+
+	_, err = c.resolve(ConflictPolicyFail, "label", "bug")
+	assert.EqualError(t, err, "label already exists on the target with different description, color: bug (conflict policy: fail)")
+
+	update, err = (&conflict{}).resolve(ConflictPolicyFail, "label", "bug")
+	assert.Nil(t, err)
+	assert.Nil(t, update)
+}
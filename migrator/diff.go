@@ -66,6 +66,22 @@ func truncateDiff(diff string) string {
 	return str
 }
 
+var diffHeaderRe = regexp.MustCompile(`(?m)^diff --git a/(.+) b/(.+)$`)
+
+// diffPaths lists the paths of the files (both the old and new paths of the
+// renamed files) in the diff.
+func diffPaths(diff string) []string {
+	var paths []string
+	for _, xs := range diffHeaderRe.FindAllStringSubmatch(diff, -1) {
+		for _, path := range xs[1:] {
+			if !containsString(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
+
 var backquoteRe = regexp.MustCompile("((?:^|\n) *)```")
 
 func escapeBackQuotes(src string) string {
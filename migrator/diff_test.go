@@ -116,3 +116,19 @@ index 1234567..89abcde 100644
 		assert.Equal(t, tc.expected, truncateDiff(tc.src))
 	}
 }
+
+func TestDiffPaths(t *testing.T) {
+	diff := `diff --git a/web/index.html b/web/index.html
+index 1234567..89abcde 100644
+--- a/web/index.html
++++ b/web/index.html
+@@ -1 +1 @@
+-diff --git a/not/a/header b/not/a/header
++added
+diff --git a/api/old.go b/api/new.go
+similarity index 100%
+rename from api/old.go
+rename to api/new.go
+`
+	assert.Equal(t, []string{"web/index.html", "api/old.go", "api/new.go"}, diffPaths(diff))
+}
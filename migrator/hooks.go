@@ -22,19 +22,8 @@ func (m *migrator) migrateHooks() error {
 		for _, targetHook := range targetHooks {
 			if sourceHook.Name == targetHook.Name &&
 				sourceHook.Config.URL == targetHook.Config.URL {
-				if sourceHook.Active != targetHook.Active ||
-					!reflect.DeepEqual(sourceHook.Events, targetHook.Events) ||
-					!reflect.DeepEqual(sourceHook.Config, targetHook.Config) {
-					fmt.Printf("[|>] updating an existing hook: %s\n", targetHook.Config.URL)
-					if _, err := m.target.UpdateHook(targetHook.ID, &github.UpdateHookParams{
-						Active: sourceHook.Active,
-						Events: sourceHook.Events,
-						Config: sourceHook.Config,
-					}); err != nil {
-						return err
-					}
-				} else {
-					fmt.Printf("[--] skipping: %s (already exists)\n", sourceHook.Config.URL)
+				if err := m.updateHook(sourceHook, targetHook); err != nil {
+					return err
 				}
 				exists = true
 				break
@@ -54,3 +43,51 @@ func (m *migrator) migrateHooks() error {
 	}
 	return nil
 }
+
+func (m *migrator) updateHook(sourceHook, targetHook *github.Hook) error {
+	mergedConfig := mergeHookConfig(targetHook.Config, sourceHook.Config)
+	var c conflict
+	c.add("active", sourceHook.Active != targetHook.Active, false)
+	c.add("events", !reflect.DeepEqual(sourceHook.Events, targetHook.Events), len(targetHook.Events) == 0)
+	c.add("config", !reflect.DeepEqual(sourceHook.Config, targetHook.Config),
+		!reflect.DeepEqual(mergedConfig, targetHook.Config))
+	update, err := c.resolve(m.conflictPolicies.Hooks, "hook", targetHook.Config.URL)
+	if err != nil || update == nil {
+		return err
+	}
+	params := &github.UpdateHookParams{
+		Active: targetHook.Active,
+		Events: targetHook.Events,
+		Config: targetHook.Config,
+	}
+	if update["active"] {
+		params.Active = sourceHook.Active
+	}
+	if update["events"] {
+		params.Events = sourceHook.Events
+	}
+	if update["config"] {
+		if m.conflictPolicies.Hooks == ConflictPolicyMergeMissingFields {
+			params.Config = mergedConfig
+		} else {
+			params.Config = sourceHook.Config
+		}
+	}
+	_, err = m.target.UpdateHook(targetHook.ID, params)
+	return err
+}
+
+// mergeHookConfig fills the empty fields of the config with the other one.
+func mergeHookConfig(config, other *github.HookConfig) *github.HookConfig {
+	merged := *config
+	if merged.ContentType == "" {
+		merged.ContentType = other.ContentType
+	}
+	if merged.InsecureSsl == "" {
+		merged.InsecureSsl = other.InsecureSsl
+	}
+	if merged.Secret == "" {
+		merged.Secret = other.Secret
+	}
+	return &merged
+}
@@ -0,0 +1,223 @@
+package migrator
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MigratorHostLinks returns a migrator option to rewrite the links to the
+// source host other than the repository; the pages of the repositories, the
+// users and the organizations, the raw files and the API URLs are mapped to
+// the target host with the repository mapping and the user mapping, and the
+// host-relative links (/owner/repo/issues/1) are resolved against the source
+// host. The links which cannot be mapped (including the gists) are kept as
+// they are, and written to the report file, merged with the report of the
+// previous runs.
+func MigratorHostLinks(reportPath string) MigratorOption {
+	return func(m *migrator) {
+		m.hostLinks = &hostLinks{reportPath: reportPath}
+	}
+}
+
+type hostLinks struct {
+	reportPath string
+	location   string
+	report     []*hostLinkReport
+	previous   []*hostLinkReport
+	loaded     bool
+}
+
+type hostLinkReport struct {
+	Location string `json:"location"`
+	URL      string `json:"url"`
+	Kind     string `json:"kind"`
+}
+
+func (h *hostLinks) reportLink(link, kind string) {
+	for _, r := range h.report {
+		if r.Location == h.location && r.URL == link {
+			return
+		}
+	}
+	fmt.Printf("[??] unmapped link: %s (%s in %s)\n", link, kind, h.location)
+	h.report = append(h.report, &hostLinkReport{Location: h.location, URL: link, Kind: kind})
+}
+
+// loadReport loads the report of the previous runs, which has the unmapped
+// links in the issues skipped on this run.
+func (h *hostLinks) loadReport() error {
+	if h.reportPath == "" || h.loaded {
+		return nil
+	}
+	h.loaded = true
+	return loadReport(h.reportPath, &h.previous)
+}
+
+// saveReport writes the report, replacing the entries of the previous runs
+// with the same location and link.
+func (h *hostLinks) saveReport() error {
+	if h.reportPath == "" {
+		return nil
+	}
+	type key struct{ location, url string }
+	keys := make(map[key]bool, len(h.report))
+	for _, x := range h.report {
+		keys[key{x.Location, x.URL}] = true
+	}
+	report := []*hostLinkReport{}
+	for _, x := range h.previous {
+		if !keys[key{x.Location, x.URL}] {
+			report = append(report, x)
+		}
+	}
+	return saveReport(h.reportPath, append(report, h.report...))
+}
+
+func (m *migrator) saveHostLinksReport() error {
+	if m.hostLinks == nil {
+		return nil
+	}
+	return m.hostLinks.saveReport()
+}
+
+// newRelativeLinkFilter creates a filter to resolve the host-relative links in
+// the HTML attributes and the Markdown links against the source host. This
+// filter should be applied before the other filters rewrite the links.
+func (m *migrator) newRelativeLinkFilter() commentFilter {
+	sourceURL, _ := url.Parse(m.sourceRepo.HTMLURL)
+	sourceBase := sourceURL.Scheme + "://" + sourceURL.Host
+	htmlPattern := regexp.MustCompile(`(?i)(\s(?:href|src)=")(/[^/"][^"]*)"`)
+	markdownPattern := regexp.MustCompile(`(\]\()(/[^/\s)][^\s)]*)\)`)
+	return commentFilter(func(src string) string {
+		src = htmlPattern.ReplaceAllString(src, `${1}`+sourceBase+`${2}"`)
+		return markdownPattern.ReplaceAllString(src, `${1}`+sourceBase+`${2})`)
+	})
+}
+
+// newHostLinksFilter creates a filter to rewrite the links to the source host.
+// This filter should be applied after the repository urls are rewritten, and
+// before the users are mapped (the logins in the user links are mapped by the
+// user mapping filter).
+func (m *migrator) newHostLinksFilter() (commentFilter, error) {
+	if err := m.hostLinks.loadReport(); err != nil {
+		return nil, err
+	}
+	sourceURL, _ := url.Parse(m.sourceRepo.HTMLURL)
+	targetURL, _ := url.Parse(m.targetRepo.HTMLURL)
+	if strings.EqualFold(sourceURL.Host, targetURL.Host) {
+		return commentFilter(func(src string) string {
+			return src
+		}), nil
+	}
+	pattern := regexp.MustCompile(
+		`(?i)https?://(?:(api|gist|raw)\.)?` + regexp.QuoteMeta(sourceURL.Host) + `(/[^\s"'<>()\[\]]*)?`,
+	)
+	return commentFilter(func(src string) string {
+		return pattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := pattern.FindStringSubmatch(s)
+			path := strings.TrimRight(xs[2], ".,:;!?")
+			link, suffix := s[:len(s)-len(xs[2])+len(path)], xs[2][len(path):]
+			var query string
+			if i := strings.IndexAny(path, "?#"); i >= 0 {
+				path, query = path[:i], path[i:]
+			}
+			if newLink, kind := m.mapHostLink(strings.ToLower(xs[1]), path, targetURL); kind != "" {
+				m.hostLinks.reportLink(link, kind)
+			} else {
+				link = newLink + query
+			}
+			return link + suffix
+		})
+	}), nil
+}
+
+// mapHostLink maps the path on the source host (or the subdomain) to the link
+// on the target host, or returns the kind of the link when it cannot be mapped.
+func (m *migrator) mapHostLink(subdomain, path string, targetURL *url.URL) (string, string) {
+	targetBase := targetURL.Scheme + "://" + targetURL.Host
+	xs := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	switch {
+	case subdomain == "gist" || xs[0] == "gist":
+		return "", "gist"
+	case subdomain == "raw" || xs[0] == "raw":
+		if subdomain == "" {
+			xs = strings.SplitN(strings.TrimPrefix(path, "/raw/"), "/", 3)
+		}
+		if len(xs) < 3 {
+			return "", "raw"
+		}
+		target, ok := m.lookupTargetRepoName(xs[0] + "/" + xs[1])
+		if !ok {
+			return "", "raw"
+		}
+		if targetURL.Host == "github.com" {
+			return "https://raw.githubusercontent.com/" + target + "/" + xs[2], ""
+		}
+		return targetBase + "/raw/" + target + "/" + xs[2], ""
+	case subdomain == "api" || xs[0] == "api":
+		if subdomain == "" {
+			path = strings.TrimPrefix(strings.TrimPrefix(path, "/api"), "/v3")
+		}
+		apiBase := targetBase + "/api/v3"
+		if targetURL.Host == "github.com" {
+			apiBase = "https://api." + targetURL.Host
+		}
+		xs = strings.SplitN(strings.TrimPrefix(path, "/"), "/", 4)
+		switch {
+		case xs[0] == "repos" && len(xs) >= 3:
+			if target, ok := m.lookupTargetRepoName(xs[1] + "/" + xs[2]); ok {
+				return apiBase + "/repos/" + target + strings.TrimPrefix(path, "/repos/"+xs[1]+"/"+xs[2]), ""
+			}
+		case (xs[0] == "users" || xs[0] == "orgs") && len(xs) >= 2:
+			if owner, ok := m.lookupTargetOwner(xs[1]); ok {
+				return apiBase + "/" + xs[0] + "/" + owner + strings.TrimPrefix(path, "/"+xs[0]+"/"+xs[1]), ""
+			}
+		}
+		return "", "api"
+	case xs[0] == "":
+		return targetBase + path, ""
+	case xs[0] == "orgs" && len(xs) >= 2:
+		if owner, ok := m.lookupTargetOwner(xs[1]); ok {
+			return targetBase + "/orgs/" + owner + strings.TrimPrefix(path, "/orgs/"+xs[1]), ""
+		}
+		return "", "org"
+	case len(xs) == 1 || xs[1] == "":
+		if owner, ok := m.lookupTargetOwner(xs[0]); ok {
+			return targetBase + "/" + owner + strings.TrimPrefix(path, "/"+xs[0]), ""
+		}
+		return "", "user"
+	default:
+		if target, ok := m.lookupTargetRepoName(xs[0] + "/" + xs[1]); ok {
+			return targetBase + "/" + target + strings.TrimPrefix(path, "/"+xs[0]+"/"+xs[1]), ""
+		}
+		return "", "repo"
+	}
+}
+
+// lookupTargetRepoName returns the full name of the target repository of the
+// source repository or the mapped repository.
+func (m *migrator) lookupTargetRepoName(name string) (string, bool) {
+	if strings.EqualFold(name, m.sourceRepo.FullName) {
+		return m.targetRepo.FullName, true
+	}
+	if e := m.lookupRepoMapping(name); e != nil {
+		return e.Target, true
+	}
+	return "", false
+}
+
+// lookupTargetOwner returns the owner on the target of the user or the
+// organization; the owner of the source repository is mapped to the owner of
+// the target repository, and the users in the user mapping are kept (mapped
+// by the user mapping filter later).
+func (m *migrator) lookupTargetOwner(name string) (string, bool) {
+	if strings.EqualFold(name, m.sourceRepo.FullName[:strings.IndexByte(m.sourceRepo.FullName, '/')]) {
+		return m.targetRepo.FullName[:strings.IndexByte(m.targetRepo.FullName, '/')], true
+	}
+	if _, ok := m.userMapping[name]; ok {
+		return name, true
+	}
+	return "", false
+}
@@ -0,0 +1,102 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestHostLinksFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unmapped-links.json")
+	m := &migrator{
+		sourceRepo:  &github.Repo{FullName: "team/source", HTMLURL: "https://ghe.example.com/team/source"},
+		targetRepo:  &github.Repo{FullName: "new-team/source", HTMLURL: "https://github.com/new-team/source"},
+		userMapping: map[string]string{"alice": "alice-new"},
+	}
+	MigratorHostLinks(path)(m)
+	MigratorRepoMapping(RepoMapping{"team/lib": {Target: "new-team/lib"}})(m)
+	m.setLocation("https://ghe.example.com/team/source/issues/1")
+	filter, err := m.newHostLinksFilter()
+	require.NoError(t, err)
+	filters := newCommentFilters(
+		m.newRelativeLinkFilter(),
+		m.newRepoMappingFilter(),
+		newRepoURLFilter(m.sourceRepo, m.targetRepo),
+		filter,
+		newUserMappingFilter(m.userMapping, m.targetRepo),
+	)
+	for _, tc := range []struct {
+		src, expected string
+	}{
+		{
+			"See https://ghe.example.com/team/source/pull/1/files and https://ghe.example.com/Team/Source/compare/a...b.",
+			"See https://github.com/new-team/source/pull/1/files and https://github.com/new-team/source/compare/a...b.",
+		},
+		{
+			"https://ghe.example.com/alice, https://ghe.example.com/team?tab=repositories https://ghe.example.com/orgs/team/teams",
+			"https://github.com/alice-new, https://github.com/new-team?tab=repositories https://github.com/orgs/new-team/teams",
+		},
+		{
+			"https://ghe.example.com/raw/team/lib/master/README.md https://raw.ghe.example.com/team/source/master/go.mod",
+			"https://raw.githubusercontent.com/new-team/lib/master/README.md https://raw.githubusercontent.com/new-team/source/master/go.mod",
+		},
+		{
+			"https://ghe.example.com/api/v3/repos/team/source/issues/1 https://ghe.example.com/api/v3/users/alice",
+			"https://api.github.com/repos/new-team/source/issues/1 https://api.github.com/users/alice-new",
+		},
+		{
+			`<a href="/team/source/issues/1">#1</a> [lib](/team/lib/blob/master/main.go)`,
+			`<a href="https://github.com/new-team/source/issues/1">#1</a> [lib](https://github.com/new-team/lib/blob/master/main.go)`,
+		},
+		{
+			"https://ghe.example.com/bob https://ghe.example.com/team/other/issues/1 https://gist.ghe.example.com/alice/1234 https://ghe.example.com/api/v3/teams/1",
+			"https://ghe.example.com/bob https://ghe.example.com/team/other/issues/1 https://gist.ghe.example.com/alice/1234 https://ghe.example.com/api/v3/teams/1",
+		},
+	} {
+		assert.Equal(t, tc.expected, filters.apply(tc.src))
+	}
+	filters.apply("https://ghe.example.com/bob")
+	require.NoError(t, m.saveHostLinksReport())
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"location": "https://ghe.example.com/team/source/issues/1", "url": "https://ghe.example.com/bob", "kind": "user"},
+  {"location": "https://ghe.example.com/team/source/issues/1", "url": "https://ghe.example.com/team/other/issues/1", "kind": "repo"},
+  {"location": "https://ghe.example.com/team/source/issues/1", "url": "https://gist.ghe.example.com/alice/1234", "kind": "gist"},
+  {"location": "https://ghe.example.com/team/source/issues/1", "url": "https://ghe.example.com/api/v3/teams/1", "kind": "api"}
+]`, string(bs))
+}
+
+func TestHostLinksReportResume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "unmapped-links.json")
+	run := func(issues ...string) {
+		m := &migrator{
+			sourceRepo: &github.Repo{FullName: "team/source", HTMLURL: "https://ghe.example.com/team/source"},
+			targetRepo: &github.Repo{FullName: "new-team/source", HTMLURL: "https://github.com/new-team/source"},
+		}
+		MigratorHostLinks(path)(m)
+		filter, err := m.newHostLinksFilter()
+		require.NoError(t, err)
+		for _, issue := range issues {
+			m.setLocation(issue)
+			filter("See https://ghe.example.com/team/other/issues/1")
+			require.NoError(t, m.saveHostLinksReport())
+		}
+	}
+	run("https://ghe.example.com/team/source/issues/1", "https://ghe.example.com/team/source/issues/2")
+	// the resumed run skips the issue 1, and builds the issue 2 again
+	run("https://ghe.example.com/team/source/issues/2", "https://ghe.example.com/team/source/issues/3")
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"location": "https://ghe.example.com/team/source/issues/1", "url": "https://ghe.example.com/team/other/issues/1", "kind": "repo"},
+  {"location": "https://ghe.example.com/team/source/issues/2", "url": "https://ghe.example.com/team/other/issues/1", "kind": "repo"},
+  {"location": "https://ghe.example.com/team/source/issues/3", "url": "https://ghe.example.com/team/other/issues/1", "kind": "repo"}
+]`, string(bs))
+}
@@ -18,6 +18,9 @@ var (
 func (m *migrator) migrateIssues() error {
 	sourceIssues := m.source.ListIssues()
 	targetIssuesBuffer := newIssuesBuffer(m.target.ListIssues())
+	if err := m.reserveIssueNumbers(); err != nil {
+		return err
+	}
 	var lastIssueNumber int
 	for {
 		issue, err := sourceIssues.Next()
@@ -27,6 +30,10 @@ func (m *migrator) migrateIssues() error {
 			}
 			break
 		}
+		// the deleted issues are skipped when renumbered to the next free numbers
+		if m.renumbering != nil && m.renumbering.Offset == 0 {
+			lastIssueNumber = issue.Number - 1
+		}
 		for ; issue.Number > lastIssueNumber; lastIssueNumber++ {
 			issue := issue
 			var deleted bool
@@ -39,24 +46,33 @@ func (m *migrator) migrateIssues() error {
 					ClosedAt:  issue.CreatedAt,
 				}
 			}
-			result, err := m.migrateIssue(issue, targetIssuesBuffer, deleted, false)
+			if err := m.migrateIssueAndWait(issue, targetIssuesBuffer, deleted); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// migrateIssueAndWait migrates the issue and waits for the import, retrying
+// without the assignee when the assignee is not accepted.
+func (m *migrator) migrateIssueAndWait(issue *github.Issue, targetIssuesBuffer *issuesBuffer, deleted bool) error {
+	result, err := m.migrateIssue(issue, targetIssuesBuffer, deleted, false)
+	if err != nil {
+		return err
+	}
+	if result != nil {
+		if err := m.waitImportIssue(result.ID, issue); err != nil {
+			if !strings.Contains(err.Error(), "Issue.assignee") {
+				return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
+			}
+			result, err := m.migrateIssue(issue, targetIssuesBuffer, deleted, true)
 			if err != nil {
 				return err
 			}
 			if result != nil {
 				if err := m.waitImportIssue(result.ID, issue); err != nil {
-					if !strings.Contains(err.Error(), "Issue.assignee") {
-						return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
-					}
-					result, err := m.migrateIssue(issue, targetIssuesBuffer, deleted, true)
-					if err != nil {
-						return err
-					}
-					if result != nil {
-						if err := m.waitImportIssue(result.ID, issue); err != nil {
-							return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
-						}
-					}
+					return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
 				}
 			}
 		}
@@ -67,36 +83,76 @@ func (m *migrator) migrateIssues() error {
 func (m *migrator) migrateIssue(
 	sourceIssue *github.Issue, targetIssuesBuffer *issuesBuffer, deleted, skipAssignee bool,
 ) (*github.ImportResult, error) {
-	fmt.Printf("[=>] migrating an issue: %s\n", sourceIssue.HTMLURL)
-	targetIssue, err := targetIssuesBuffer.get(sourceIssue.Number)
+	number := m.lookupIssueNumber(sourceIssue.Number)
+	if number != sourceIssue.Number {
+		fmt.Printf("[=>] migrating an issue: %s => #%d\n", sourceIssue.HTMLURL, number)
+	} else {
+		fmt.Printf("[=>] migrating an issue: %s\n", sourceIssue.HTMLURL)
+	}
+	targetIssue, err := targetIssuesBuffer.get(number)
 	if err != nil {
 		return nil, err
 	}
 	if targetIssue != nil {
 		fmt.Printf("[--] skipping: %s (already exists)\n", targetIssue.HTMLURL)
 		m.cacheIssueID(targetIssue.Number, targetIssue.ID)
+		if !deleted {
+			m.queueAssignees(sourceIssue, assigneeLogins(targetIssue)...)
+		}
+		if m.useIssuesAPI {
+			return nil, m.resumeIssue(sourceIssue, targetIssue, targetIssuesBuffer, deleted, skipAssignee)
+		}
 		return nil, nil
 	}
 	time.Sleep(beforeImportIssueDuration)
 	if deleted {
 		fmt.Printf("[>>] creating a new issue: (original: %s is deleted)\n", sourceIssue.HTMLURL)
-		return m.target.Import(&github.Import{
-			Issue: &github.ImportIssue{
-				Title: "[Deleted issue]",
-				Body: fmt.Sprintf(`<table>
-<tr>
-  <td>This issue was imported from %s, which has already been deleted.</td>
-</tr>
-</table>
-`, buildIssueLinkTag(m.sourceRepo, sourceIssue)),
-				CreatedAt: sourceIssue.CreatedAt,
-				UpdatedAt: sourceIssue.UpdatedAt,
-				Closed:    true,
-				ClosedAt:  sourceIssue.ClosedAt,
-			},
-			Comments: []*github.ImportComment{},
-		})
+		imp, err := m.buildDeletedIssueImport(sourceIssue)
+		if err != nil {
+			return nil, err
+		}
+		return m.importIssue(number, imp)
+	}
+	imp, err := m.buildIssueImport(sourceIssue, skipAssignee)
+	if err != nil {
+		return nil, err
 	}
+	fmt.Printf("[>>] creating a new issue: (original: %s)\n", sourceIssue.HTMLURL)
+	m.queueAssignees(sourceIssue, imp.Issue.Assignee)
+	return m.importIssue(number, imp)
+}
+
+// buildDeletedIssueImport builds the import payload of the placeholder issue
+// for the deleted source issue.
+func (m *migrator) buildDeletedIssueImport(sourceIssue *github.Issue) (*github.Import, error) {
+	body, err := m.render("deleted_issue", &issueData{
+		Type:        sourceIssue.Type().String(),
+		Number:      sourceIssue.Number,
+		Title:       m.filterTitle(sourceIssue.Title),
+		CreatedAt:   sourceIssue.CreatedAt,
+		OriginalURL: sourceIssue.HTMLURL,
+		SourceRepo:  m.sourceRepo.FullName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &github.Import{
+		Issue: &github.ImportIssue{
+			Title:     "[Deleted issue]",
+			Body:      body,
+			CreatedAt: sourceIssue.CreatedAt,
+			UpdatedAt: sourceIssue.UpdatedAt,
+			Closed:    true,
+			ClosedAt:  sourceIssue.ClosedAt,
+		},
+		Comments: []*github.ImportComment{},
+	}, nil
+}
+
+// buildIssueImport fetches the comments, events (and the pull request details)
+// of the source issue, and builds the import payload.
+func (m *migrator) buildIssueImport(sourceIssue *github.Issue, skipAssignee bool) (*github.Import, error) {
+	m.setLocation(sourceIssue.HTMLURL)
 	comments, err := github.CommentsToSlice(m.source.ListComments(sourceIssue.Number))
 	if err != nil {
 		return nil, err
@@ -124,6 +180,7 @@ func (m *migrator) migrateIssue(
 		if err != nil {
 			return nil, err
 		}
+		commitDiff = m.redact(commitDiff)
 		reviews, err = github.ReviewsToSlice(m.source.ListReviews(sourceIssue.Number))
 		if err != nil {
 			return nil, err
@@ -133,15 +190,36 @@ func (m *migrator) migrateIssue(
 			return nil, err
 		}
 	}
+	reactions, err := m.listReactions(sourceIssue, comments, reviewComments)
+	if err != nil {
+		return nil, err
+	}
 	imp, err := m.buildImport(
 		sourceIssue, sourcePullReq, comments, events,
 		commits, commitDiff, reviews, reviewComments,
-		skipAssignee,
+		reactions, skipAssignee,
 	)
 	if err != nil {
 		return nil, err
 	}
-	fmt.Printf("[>>] creating a new issue: (original: %s)\n", sourceIssue.HTMLURL)
+	if err := m.saveRedactionReport(); err != nil {
+		return nil, err
+	}
+	if err := m.saveHostLinksReport(); err != nil {
+		return nil, err
+	}
+	if err := m.saveAttachmentsReport(); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+// importIssue imports the issue, or creates it with the Issues API (then the
+// result is nil since there is nothing to wait for).
+func (m *migrator) importIssue(number int, imp *github.Import) (*github.ImportResult, error) {
+	if m.useIssuesAPI {
+		return nil, m.createIssue(number, imp)
+	}
 	return m.target.Import(imp)
 }
 
@@ -0,0 +1,127 @@
+package migrator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// createIssue creates the issue with the Issues API, producing the same issue
+// as the import API except for the creation dates. The issue should be created
+// with the same number as the source issue, so check the next number before
+// creating, and the created number (the deleted issues are not listed).
+func (m *migrator) createIssue(number int, imp *github.Import) error {
+	if err := m.checkNextIssueNumber(number); err != nil {
+		return err
+	}
+	params := &github.CreateIssueParams{
+		Title:     imp.Issue.Title,
+		Body:      imp.Issue.Body,
+		Labels:    imp.Issue.Labels,
+		Milestone: imp.Issue.Milestone,
+	}
+	if imp.Issue.Assignee != "" {
+		params.Assignees = []string{imp.Issue.Assignee}
+	}
+	issue, err := m.target.CreateIssue(params)
+	if err != nil {
+		return err
+	}
+	m.lastTargetIssueNumber = issue.Number
+	if issue.Number != number {
+		fmt.Printf("[!!] created an unexpected issue: %s (delete or transfer it before the next run)\n", issue.HTMLURL)
+		return fmt.Errorf("unexpected issue number: %s (expected #%d)", issue.HTMLURL, number)
+	}
+	m.cacheIssueID(issue.Number, issue.ID)
+	return m.completeIssue(issue, imp, nil)
+}
+
+// checkNextIssueNumber checks that the next issue created on the target gets
+// the number. The last number is reloaded since the other migrators (merging
+// the repositories) may have created the issues.
+func (m *migrator) checkNextIssueNumber(number int) error {
+	if m.lastTargetIssueNumber+1 == number {
+		return nil
+	}
+	if err := m.loadLastTargetIssueNumber(); err != nil {
+		return err
+	}
+	if m.lastTargetIssueNumber+1 != number {
+		return fmt.Errorf("cannot create the issue #%d: the target has issues up to #%d", number, m.lastTargetIssueNumber)
+	}
+	return nil
+}
+
+// resumeIssue completes the issue created by the interrupted migration with the
+// Issues API; posts the missing comments and closes the issue. The issues are
+// created in order, so the issue followed by the next issue is complete. The
+// issue and the posted comments should be the ones built from the source, not
+// the issue created by others.
+func (m *migrator) resumeIssue(
+	sourceIssue, targetIssue *github.Issue, targetIssuesBuffer *issuesBuffer, deleted, skipAssignee bool,
+) error {
+	nextIssue, err := targetIssuesBuffer.get(targetIssue.Number + 1)
+	if err != nil || nextIssue != nil {
+		return err
+	}
+	var imp *github.Import
+	if deleted {
+		imp, err = m.buildDeletedIssueImport(sourceIssue)
+	} else {
+		imp, err = m.buildIssueImport(sourceIssue, skipAssignee)
+	}
+	if err != nil {
+		return err
+	}
+	if targetIssue.Title != imp.Issue.Title || targetIssue.Body != imp.Issue.Body {
+		return fmt.Errorf("cannot resume the issue: %s (not created from %s)", targetIssue.HTMLURL, sourceIssue.HTMLURL)
+	}
+	posted, err := github.CommentsToSlice(m.target.ListComments(targetIssue.Number))
+	if err != nil {
+		return err
+	}
+	return m.completeIssue(targetIssue, imp, posted)
+}
+
+// completeIssue posts the comments after the posted ones, and closes the issue.
+func (m *migrator) completeIssue(issue *github.Issue, imp *github.Import, posted []*github.Comment) error {
+	comments := sortImportComments(imp.Comments)
+	if len(posted) > len(comments) {
+		return fmt.Errorf("unexpected comments: %s (%d comments but expected %d)",
+			issue.HTMLURL, len(posted), len(comments))
+	}
+	for i, c := range posted {
+		if c.Body != comments[i].Body {
+			return fmt.Errorf("unexpected comment: %s (not created from the source)", c.HTMLURL)
+		}
+	}
+	if len(posted) > 0 && len(posted) < len(comments) {
+		fmt.Printf("[|>] resuming the issue: %s (posted %d of %s)\n",
+			issue.HTMLURL, len(posted), plural(len(comments), "comment"))
+	}
+	for _, c := range comments[len(posted):] {
+		if _, err := m.target.CreateComment(issue.Number, c.Body); err != nil {
+			return err
+		}
+	}
+	if imp.Issue.Closed && issue.State != github.IssueStateClosed {
+		fmt.Printf("[|>] closing the issue: %s\n", issue.HTMLURL)
+		if _, err := m.target.UpdateIssue(issue.Number, &github.UpdateIssueParams{
+			State: github.IssueStateClosed,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortImportComments sorts the comments in the created order, as the import API does.
+func sortImportComments(cs []*github.ImportComment) []*github.ImportComment {
+	xs := make([]*github.ImportComment, len(cs))
+	copy(xs, cs)
+	sort.SliceStable(xs, func(i, j int) bool {
+		return xs[i].CreatedAt < xs[j].CreatedAt
+	})
+	return xs
+}
@@ -0,0 +1,60 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestCreateIssueNumber(t *testing.T) {
+	var created []string
+	m := &migrator{
+		target: repo.New(github.NewMockClient(
+			github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+				return github.IssuesFromSlice([]*github.Issue{{Number: 1}, {Number: 3}})
+			}),
+			github.MockCreateIssue(func(_ string, params *github.CreateIssueParams) (*github.Issue, error) {
+				created = append(created, params.Title)
+				return &github.Issue{Number: 5, HTMLURL: "http://localhost/example/target/issues/5"}, nil
+			}),
+			github.MockCreateComment(func(string, int, string) (*github.Comment, error) {
+				t.Fatal("CreateComment should not be called")
+				return nil, nil
+			}),
+		), "example/target"),
+	}
+	imp := &github.Import{
+		Issue:    &github.ImportIssue{Title: "Example title"},
+		Comments: []*github.ImportComment{{Body: "Example comment"}},
+	}
+	assert.EqualError(t, m.createIssue(2, imp), "cannot create the issue #2: the target has issues up to #3")
+	assert.Nil(t, created)
+
+	// the deleted issue #4 is not listed
+	assert.EqualError(t, m.createIssue(4, imp), "unexpected issue number: http://localhost/example/target/issues/5 (expected #4)")
+	assert.Equal(t, []string{"Example title"}, created)
+}
+
+func TestCompleteIssueUnexpectedComment(t *testing.T) {
+	m := &migrator{
+		target: repo.New(github.NewMockClient(
+			github.MockCreateComment(func(string, int, string) (*github.Comment, error) {
+				t.Fatal("CreateComment should not be called")
+				return nil, nil
+			}),
+		), "example/target"),
+	}
+	imp := &github.Import{
+		Issue: &github.ImportIssue{Title: "Example title"},
+		Comments: []*github.ImportComment{
+			{Body: "Example comment 1", CreatedAt: "2019-11-18T12:00:00Z"},
+			{Body: "Example comment 2", CreatedAt: "2019-11-18T13:00:00Z"},
+		},
+	}
+	assert.EqualError(t, m.completeIssue(&github.Issue{Number: 1}, imp, []*github.Comment{
+		{Body: "Other comment", HTMLURL: "http://localhost/example/target/issues/1#issuecomment-1"},
+	}), "unexpected comment: http://localhost/example/target/issues/1#issuecomment-1 (not created from the source)")
+}
@@ -7,32 +7,106 @@ import (
 	"github.com/itchyny/github-migrator/github"
 )
 
+// LabelMappingEntry is an entry of the label mapping. The labels are renamed
+// to the name (multiple labels mapped to the same name are merged), or dropped
+// when drop is true. The color and the description of the source label are
+// used unless specified.
+type LabelMappingEntry struct {
+	Name        string
+	Color       string
+	Description string
+	Drop        bool
+}
+
+// LabelMapping maps the labels of the source by the names.
+type LabelMapping map[string]*LabelMappingEntry
+
+// Validate validates the label mapping.
+func (lm LabelMapping) Validate() error {
+	for name, e := range lm {
+		if e == nil || e.Name == "" && !e.Drop {
+			return fmt.Errorf("label mapping %s: specify name or drop", name)
+		}
+		if e.Name != "" && e.Drop {
+			return fmt.Errorf("label mapping %s: specify either name or drop", name)
+		}
+		e.Color = strings.TrimPrefix(e.Color, "#")
+	}
+	return nil
+}
+
+// MigratorLabelMapping returns a migrator option to rename, merge and drop the
+// labels, in the labels of the target repository and the issues, and the label
+// events.
+func MigratorLabelMapping(mapping LabelMapping) MigratorOption {
+	return func(m *migrator) {
+		m.labelMapping = make(LabelMapping, len(mapping))
+		for k, v := range mapping {
+			m.labelMapping[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// lookupLabel returns the label name on the target, or an empty string when
+// the label is dropped.
+func (m *migrator) lookupLabel(name string) string {
+	if e, ok := m.labelMapping[strings.ToLower(name)]; ok {
+		return e.Name
+	}
+	return name
+}
+
+// mapLabels maps the label names, removing the dropped and merged ones.
+func (m *migrator) mapLabels(names []string) []string {
+	xs := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = m.lookupLabel(name); name != "" && !containsLabel(xs, name) {
+			xs = append(xs, name)
+		}
+	}
+	return xs
+}
+
+func containsLabel(xs []string, x string) bool {
+	for _, y := range xs {
+		if strings.EqualFold(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *migrator) migrateLabels() error {
 	sourceLabels, err := github.LabelsToSlice(m.source.ListLabels())
 	if err != nil {
 		return err
 	}
+	if m.sourceLabel != "" {
+		sourceLabels = append(sourceLabels, &github.Label{
+			Name:        m.sourceLabel,
+			Description: "Migrated from " + m.sourceRepo.FullName,
+			Color:       "ededed",
+		})
+	}
 	targetLabels, err := github.LabelsToSlice(m.target.ListLabels())
 	if err != nil {
 		return err
 	}
+	var migratedLabels []string
 	for _, sourceLabel := range sourceLabels {
-		fmt.Printf("[=>] migrating a label: %s\n", sourceLabel.Name)
+		if sourceLabel = m.buildTargetLabel(sourceLabel); sourceLabel == nil {
+			continue
+		}
+		if containsLabel(migratedLabels, sourceLabel.Name) {
+			fmt.Printf("[--] skipping: %s (already merged)\n", sourceLabel.Name)
+			continue
+		}
+		migratedLabels = append(migratedLabels, sourceLabel.Name)
 		var exists bool
 		for _, targetLabel := range targetLabels {
 			if strings.EqualFold(sourceLabel.Name, targetLabel.Name) {
-				if sourceLabel.Description != targetLabel.Description ||
-					sourceLabel.Color != targetLabel.Color {
-					fmt.Printf("[|>] updating an existing label: %s\n", targetLabel.Name)
-					if _, err := m.target.UpdateLabel(targetLabel.Name, &github.UpdateLabelParams{
-						Name:        sourceLabel.Name,
-						Description: sourceLabel.Description,
-						Color:       sourceLabel.Color,
-					}); err != nil {
-						return err
-					}
-				} else {
-					fmt.Printf("[--] skipping: %s (already exists)\n", sourceLabel.Name)
+				if err := m.updateLabel(sourceLabel, targetLabel); err != nil {
+					return err
 				}
 				exists = true
 				break
@@ -50,5 +124,54 @@ func (m *migrator) migrateLabels() error {
 			return err
 		}
 	}
-	return nil
+	return m.pruneLabels(targetLabels, migratedLabels)
+}
+
+func (m *migrator) updateLabel(sourceLabel, targetLabel *github.Label) error {
+	var c conflict
+	c.add("description", sourceLabel.Description != targetLabel.Description, targetLabel.Description == "")
+	c.add("color", sourceLabel.Color != targetLabel.Color, targetLabel.Color == "")
+	update, err := c.resolve(m.conflictPolicies.Labels, "label", targetLabel.Name)
+	if err != nil || update == nil {
+		return err
+	}
+	params := &github.UpdateLabelParams{
+		Name:        targetLabel.Name,
+		Description: targetLabel.Description,
+		Color:       targetLabel.Color,
+	}
+	if m.conflictPolicies.Labels == ConflictPolicySourceWins {
+		params.Name = sourceLabel.Name
+	}
+	if update["description"] {
+		params.Description = sourceLabel.Description
+	}
+	if update["color"] {
+		params.Color = sourceLabel.Color
+	}
+	_, err = m.target.UpdateLabel(targetLabel.Name, params)
+	return err
+}
+
+// buildTargetLabel applies the label mapping to the source label, and returns
+// nil when the label is dropped.
+func (m *migrator) buildTargetLabel(l *github.Label) *github.Label {
+	e, ok := m.labelMapping[strings.ToLower(l.Name)]
+	if !ok {
+		fmt.Printf("[=>] migrating a label: %s\n", l.Name)
+		return l
+	}
+	if e.Drop {
+		fmt.Printf("[--] dropping a label: %s\n", l.Name)
+		return nil
+	}
+	fmt.Printf("[=>] migrating a label: %s => %s\n", l.Name, e.Name)
+	x := &github.Label{Name: e.Name, Description: l.Description, Color: l.Color}
+	if e.Description != "" {
+		x.Description = e.Description
+	}
+	if e.Color != "" {
+		x.Color = e.Color
+	}
+	return x
 }
@@ -0,0 +1,30 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelMappingValidate(t *testing.T) {
+	lm := LabelMapping{"bug": {Name: "type: bug", Color: "#d73a4a"}, "wontfix": {Drop: true}}
+	assert.Nil(t, lm.Validate())
+	assert.Equal(t, "d73a4a", lm["bug"].Color)
+
+	assert.EqualError(t, LabelMapping{"bug": {}}.Validate(),
+		"label mapping bug: specify name or drop")
+	assert.EqualError(t, LabelMapping{"bug": {Name: "type: bug", Drop: true}}.Validate(),
+		"label mapping bug: specify either name or drop")
+}
+
+func TestMapLabels(t *testing.T) {
+	m := &migrator{}
+	MigratorLabelMapping(LabelMapping{
+		"Bug":     {Name: "type: bug"},
+		"defect":  {Name: "type: bug"},
+		"wontfix": {Drop: true},
+	})(m)
+	assert.Equal(t, []string{"type: bug", "enhancement"},
+		m.mapLabels([]string{"bug", "Defect", "wontfix", "enhancement", "Type: Bug"}))
+	assert.Equal(t, []string{}, m.mapLabels([]string{"wontfix"}))
+}
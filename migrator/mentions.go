@@ -0,0 +1,126 @@
+package migrator
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MentionPolicy represents how the mentions are rendered in the migrated
+// issues, to avoid notifying the users about the old threads.
+type MentionPolicy int
+
+// MentionPolicy constants.
+const (
+	MentionPolicyKeep MentionPolicy = iota
+	MentionPolicyCode
+	MentionPolicyZeroWidthJoiner
+	MentionPolicyLink
+)
+
+// ParseMentionPolicy parses the mention policy.
+func ParseMentionPolicy(s string) (MentionPolicy, error) {
+	switch s {
+	case "", "keep":
+		return MentionPolicyKeep, nil
+	case "code":
+		return MentionPolicyCode, nil
+	case "zwj":
+		return MentionPolicyZeroWidthJoiner, nil
+	case "link":
+		return MentionPolicyLink, nil
+	default:
+		return 0, fmt.Errorf("unknown mention policy: %s (specify keep, code, zwj or link)", s)
+	}
+}
+
+// MigratorMentionPolicy returns a migrator option to neutralize the mentions
+// (@user and @org/team) in the bodies and the headers, except for the users
+// in the allowlist (the logins on the target, or org/team).
+func MigratorMentionPolicy(policy MentionPolicy, allowlist []string) MigratorOption {
+	return func(m *migrator) {
+		m.mentionPolicy = policy
+		m.mentionAllowlist = make(map[string]bool, len(allowlist))
+		for _, name := range allowlist {
+			m.mentionAllowlist[strings.ToLower(name)] = true
+		}
+	}
+}
+
+func (m *migrator) isMentionAllowed(name string) bool {
+	return m.mentionPolicy == MentionPolicyKeep || m.mentionAllowlist[strings.ToLower(name)]
+}
+
+// mentionHTML renders the mention in the headers (in the HTML tables).
+func (m *migrator) mentionHTML(name string) string {
+	if m.isMentionAllowed(name) {
+		return "@" + name
+	}
+	switch m.mentionPolicy {
+	case MentionPolicyCode:
+		return "<code>@" + html.EscapeString(name) + "</code>"
+	case MentionPolicyZeroWidthJoiner:
+		return "@\u200d" + name
+	default:
+		return fmt.Sprintf(`<a href="%s">%s</a>`, m.profileURL(name), html.EscapeString(name))
+	}
+}
+
+// mentionMarkdown renders the mention in the bodies.
+func (m *migrator) mentionMarkdown(name string) string {
+	if m.isMentionAllowed(name) {
+		return "@" + name
+	}
+	switch m.mentionPolicy {
+	case MentionPolicyCode:
+		return "`@" + name + "`"
+	case MentionPolicyZeroWidthJoiner:
+		return "@\u200d" + name
+	default:
+		return fmt.Sprintf("[%s](%s)", name, m.profileURL(name))
+	}
+}
+
+func (m *migrator) profileURL(name string) string {
+	targetURL, _ := url.Parse(m.targetRepo.HTMLURL)
+	if i := strings.IndexByte(name, '/'); i >= 0 {
+		return fmt.Sprintf("%s://%s/orgs/%s/teams/%s", targetURL.Scheme, targetURL.Host, name[:i], name[i+1:])
+	}
+	return fmt.Sprintf("%s://%s/%s", targetURL.Scheme, targetURL.Host, name)
+}
+
+var mentionPattern = regexp.MustCompile(
+	`(^|[^\w@/\x60])@([a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:/[a-zA-Z0-9][\w.-]*[a-zA-Z0-9_])?)\b`,
+)
+
+// newMentionFilter creates a filter to neutralize the mentions in the bodies.
+// The mentions in the code blocks and code spans are kept as they are. This
+// filter should be applied after the user mapping filter.
+func (m *migrator) newMentionFilter() commentFilter {
+	return commentFilter(func(src string) string {
+		xs := strings.Split(src, "\n")
+		var inCode bool
+		for i, x := range xs {
+			if strings.HasPrefix(strings.TrimSpace(x), "```") {
+				inCode = !inCode
+			}
+			if inCode {
+				continue
+			}
+			ys := strings.Split(x, "`")
+			for j, y := range ys {
+				// the odd parts are in the code spans (unless the backquote is unclosed)
+				if j%2 == 0 || j == len(ys)-1 {
+					ys[j] = mentionPattern.ReplaceAllStringFunc(y, func(s string) string {
+						xs := mentionPattern.FindStringSubmatch(s)
+						return xs[1] + m.mentionMarkdown(xs[2])
+					})
+				}
+			}
+			xs[i] = strings.Join(ys, "`")
+		}
+		return strings.Join(xs, "\n")
+	})
+}
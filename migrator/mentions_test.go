@@ -0,0 +1,58 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestMentionFilter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy MentionPolicy
+		src    string
+		header string
+		body   string
+	}{
+		{
+			name:   "code",
+			policy: MentionPolicyCode,
+			src:    "@user-1, @org/team-a and @user-2 (not user@example.com)",
+			header: "<code>@user-1</code>",
+			body:   "`@user-1`, `@org/team-a` and @user-2 (not user@example.com)",
+		},
+		{
+			name:   "zero width joiner",
+			policy: MentionPolicyZeroWidthJoiner,
+			src:    "cc: @user-1 `@user-1` and `unclosed @user-1",
+			header: "@\u200duser-1",
+			body:   "cc: @\u200duser-1 `@user-1` and `unclosed @\u200duser-1",
+		},
+		{
+			name:   "link",
+			policy: MentionPolicyLink,
+			src:    "@user-1 and @org/team-a",
+			header: `<a href="https://github.com/user-1">user-1</a>`,
+			body:   "[user-1](https://github.com/user-1) and [org/team-a](https://github.com/orgs/org/teams/team-a)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &migrator{targetRepo: &github.Repo{HTMLURL: "https://github.com/example/target"}}
+			MigratorMentionPolicy(tc.policy, []string{"User-2"})(m)
+			assert.Equal(t, tc.header, m.mentionHTML("user-1"))
+			assert.Equal(t, "@user-2", m.mentionHTML("user-2"))
+			assert.Equal(t, tc.body, m.newMentionFilter()(tc.src))
+		})
+	}
+}
+
+func TestParseMentionPolicy(t *testing.T) {
+	policy, err := ParseMentionPolicy("link")
+	assert.NoError(t, err)
+	assert.Equal(t, MentionPolicyLink, policy)
+	_, err = ParseMentionPolicy("drop")
+	assert.EqualError(t, err, "unknown mention policy: drop (specify keep, code, zwj or link)")
+}
@@ -0,0 +1,194 @@
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// MergeOrder represents the order of the issues merged from the sources.
+type MergeOrder int
+
+// MergeOrder constants.
+const (
+	MergeOrderAppended MergeOrder = iota
+	MergeOrderInterleaved
+)
+
+// ParseMergeOrder parses the merge order.
+func ParseMergeOrder(s string) (MergeOrder, error) {
+	switch s {
+	case "", "appended":
+		return MergeOrderAppended, nil
+	case "interleaved":
+		return MergeOrderInterleaved, nil
+	default:
+		return 0, fmt.Errorf("unknown merge order: %s (specify appended or interleaved)", s)
+	}
+}
+
+func (o MergeOrder) String() string {
+	switch o {
+	case MergeOrderInterleaved:
+		return "interleaved"
+	default:
+		return "appended"
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (o *MergeOrder) UnmarshalText(text []byte) (err error) {
+	*o, err = ParseMergeOrder(string(text))
+	return
+}
+
+// Merge is the setting to merge the source repositories into the target. The
+// issues are appended per source or interleaved by the creation dates, and
+// renumbered to the next free numbers. The label of LabelPrefix and the source
+// repository name is added to the issues unless LabelPrefix is empty. The
+// issue number map of each source is saved next to MapFile.
+type Merge struct {
+	Order       MergeOrder
+	LabelPrefix string
+	MapFile     string
+}
+
+// NewMerger creates a new Migrator, which merges the source repositories into
+// the target repository. The labels, milestones and projects of the sources
+// are merged by the names (use the conflict policies to resolve the conflicts),
+// and the references to the issues are rewritten to the new numbers.
+func NewMerger(sources []*repo.Repo, target *repo.Repo, userMapping map[string]string, merge Merge, opts ...MigratorOption) Migrator {
+	ms := make([]*migrator, len(sources))
+	for i, source := range sources {
+		m := New(source, target, userMapping, opts...).(*migrator)
+		m.renumbering = &Renumbering{}
+		if i > 0 {
+			// share the attachments manifest and the reports
+			m.attachments, m.redaction, m.hostLinks = ms[0].attachments, ms[0].redaction, ms[0].hostLinks
+		}
+		ms[i] = m
+	}
+	return &merger{migrators: ms, merge: merge}
+}
+
+type merger struct {
+	migrators []*migrator
+	merge     Merge
+	issues    []*mergedIssue
+}
+
+type mergedIssue struct {
+	index int
+	issue *github.Issue
+}
+
+// Migrate merges the repositories.
+func (mg *merger) Migrate() error {
+	for _, m := range mg.migrators {
+		// the labels and milestones of the other sources are not migrated yet
+		if m.prune.Labels || m.prune.Milestones {
+			return errors.New("prune is not supported for merging repositories")
+		}
+	}
+	for _, m := range mg.migrators {
+		if err := m.prepare(); err != nil {
+			return err
+		}
+		m.renumbering.MapFile = mergeMapFile(mg.merge.MapFile, m.sourceRepo.FullName)
+		if mg.merge.LabelPrefix != "" {
+			m.sourceLabel = mg.merge.LabelPrefix + m.sourceRepo.Name
+		}
+	}
+	if err := mg.loadIssueNumbers(); err != nil {
+		return err
+	}
+	for _, m := range mg.migrators {
+		if err := m.migrateRepo(); err != nil {
+			return err
+		}
+		if err := m.migrateBeforeIssues(); err != nil {
+			return err
+		}
+	}
+	if err := mg.migrateIssues(); err != nil {
+		return err
+	}
+	for _, m := range mg.migrators {
+		if err := m.migrateAfterIssues(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadIssueNumbers loads the issue number maps of the sources, and assigns the
+// next free numbers to the source issues which are not in the maps yet.
+func (mg *merger) loadIssueNumbers() error {
+	nms := make([]*IssueNumberMap, len(mg.migrators))
+	for i, m := range mg.migrators {
+		nm, err := m.openIssueNumberMap()
+		if err != nil {
+			return err
+		}
+		nms[i] = nm
+		issues, err := github.IssuesToSlice(m.source.ListIssues())
+		if err != nil {
+			return err
+		}
+		for _, issue := range issues {
+			mg.issues = append(mg.issues, &mergedIssue{i, issue})
+		}
+	}
+	m := mg.migrators[0]
+	if err := m.loadLastTargetIssueNumber(); err != nil {
+		return err
+	}
+	next := nextIssueNumber(m.lastTargetIssueNumber, nms...)
+	if mg.merge.Order == MergeOrderInterleaved {
+		sort.SliceStable(mg.issues, func(i, j int) bool {
+			return mg.issues[i].issue.CreatedAt < mg.issues[j].issue.CreatedAt
+		})
+	}
+	counts := make([]int, len(nms))
+	for _, x := range mg.issues {
+		if _, ok := nms[x.index].Issues[x.issue.Number]; ok {
+			continue
+		}
+		nms[x.index].Issues[x.issue.Number] = next
+		next++
+		counts[x.index]++
+	}
+	for i, m := range mg.migrators {
+		if err := m.saveIssueNumberMap(nms[i], counts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateIssues migrates the issues of the sources in the order of the target
+// numbers, since the numbers cannot be skipped.
+func (mg *merger) migrateIssues() error {
+	sort.SliceStable(mg.issues, func(i, j int) bool {
+		return mg.migrators[mg.issues[i].index].lookupIssueNumber(mg.issues[i].issue.Number) <
+			mg.migrators[mg.issues[j].index].lookupIssueNumber(mg.issues[j].issue.Number)
+	})
+	targetIssuesBuffer := newIssuesBuffer(mg.migrators[0].target.ListIssues())
+	for _, x := range mg.issues {
+		if err := mg.migrators[x.index].migrateIssueAndWait(x.issue, targetIssuesBuffer, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeMapFile returns the path of the issue number map of the source.
+func mergeMapFile(path, fullName string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + strings.ReplaceAll(fullName, "/", "-") + ext
+}
@@ -0,0 +1,183 @@
+package migrator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+const mergeTestYAML = `
+sources:
+  - repo:
+      name: billing
+      full_name: example/billing
+      html_url: http://localhost/example/billing
+    labels:
+      - name: bug
+        color: d73a4a
+    issues:
+      - number: 1
+        title: Billing issue 1
+        body: "See #2."
+        html_url: http://localhost/example/billing/issues/1
+        state: open
+        created_at: 2019-11-18T12:00:00Z
+      - number: 2
+        title: Billing issue 2
+        html_url: http://localhost/example/billing/issues/2
+        state: open
+        created_at: 2019-11-18T14:00:00Z
+  - repo:
+      name: auth
+      full_name: example/auth
+      html_url: http://localhost/example/auth
+    labels:
+      - name: bug
+        color: d73a4a
+    issues:
+      - number: 1
+        title: Auth issue 1
+        body: "See #1 and example/auth#1."
+        html_url: http://localhost/example/auth/issues/1
+        state: open
+        labels:
+          - name: bug
+        created_at: 2019-11-18T13:00:00Z
+
+target:
+  repo:
+    name: monorepo
+    full_name: example/monorepo
+    html_url: http://localhost/example/monorepo
+  labels:
+    - name: bug
+      color: d73a4a
+  create_labels:
+    - name: repo:billing
+      description: Migrated from example/billing
+      color: ededed
+    - name: repo:auth
+      description: Migrated from example/auth
+      color: ededed
+  issues:
+    - number: 1
+      title: Existing issue
+      state: open
+  imports:
+    - issue:
+        title: Billing issue 1
+        body: |-
+          <table>
+          <tr>
+            <td width="60">
+              <img src="https://github.com/ghost.png" width="35">
+            </td>
+            <td>
+              @ghost created the original issue<br>
+              imported from <a href="http://localhost/example/billing/issues/1">example/billing#1</a>
+            </td>
+          </tr>
+          </table>
+
+
+          See #4.
+        created_at: 2019-11-18T12:00:00Z
+        closed: false
+        labels: [repo:billing]
+      comments: []
+    - issue:
+        title: Auth issue 1
+        body: |-
+          <table>
+          <tr>
+            <td width="60">
+              <img src="https://github.com/ghost.png" width="35">
+            </td>
+            <td>
+              @ghost created the original issue<br>
+              imported from <a href="http://localhost/example/auth/issues/1">example/auth#1</a>
+            </td>
+          </tr>
+          </table>
+
+
+          See #3 and example/monorepo#3.
+        created_at: 2019-11-18T13:00:00Z
+        closed: false
+        labels: [bug, repo:auth]
+      comments: []
+    - issue:
+        title: Billing issue 2
+        body: |
+          <table>
+          <tr>
+            <td width="60">
+              <img src="https://github.com/ghost.png" width="35">
+            </td>
+            <td>
+              @ghost created the original issue<br>
+              imported from <a href="http://localhost/example/billing/issues/2">example/billing#2</a>
+            </td>
+          </tr>
+          </table>
+        created_at: 2019-11-18T14:00:00Z
+        closed: false
+        labels: [repo:billing]
+      comments: []
+`
+
+func TestMergerMigrate(t *testing.T) {
+	var tc struct {
+		Sources []*testRepo `json:"sources"`
+		Target  *testRepo   `json:"target"`
+	}
+	require.NoError(t, decodeYAML(strings.NewReader(mergeTestYAML), &tc))
+	sources := make([]*repo.Repo, len(tc.Sources))
+	for i, r := range tc.Sources {
+		sources[i] = r.build(t, false)
+	}
+	target := tc.Target.build(t, true)
+	path := filepath.Join(t.TempDir(), "issue-numbers.json")
+	mg := NewMerger(sources, target, nil, Merge{
+		Order:       MergeOrderInterleaved,
+		LabelPrefix: "repo:",
+		MapFile:     path,
+	})
+	assert.Nil(t, mg.Migrate())
+
+	nm, err := LoadIssueNumberMap(filepath.Join(filepath.Dir(path), "issue-numbers-example-billing.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, &IssueNumberMap{
+		Source: "example/billing",
+		Target: "example/monorepo",
+		Issues: map[int]int{1: 2, 2: 4},
+	}, nm)
+	nm, err = LoadIssueNumberMap(filepath.Join(filepath.Dir(path), "issue-numbers-example-auth.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]int{1: 3}, nm.Issues)
+}
+
+func TestMergerMigratePrune(t *testing.T) {
+	mg := NewMerger(
+		[]*repo.Repo{repo.New(github.NewMockClient(), "example/billing")},
+		repo.New(github.NewMockClient(), "example/monorepo"),
+		nil, Merge{}, MigratorPrune(Prune{Labels: true}),
+	)
+	assert.EqualError(t, mg.Migrate(), "prune is not supported for merging repositories")
+}
+
+func TestParseMergeOrder(t *testing.T) {
+	for _, s := range []string{"appended", "interleaved"} {
+		o, err := ParseMergeOrder(s)
+		assert.Nil(t, err)
+		assert.Equal(t, s, o.String())
+	}
+	_, err := ParseMergeOrder("sorted")
+	assert.EqualError(t, err, "unknown merge order: sorted (specify appended or interleaved)")
+}
@@ -2,6 +2,7 @@ package migrator
 
 import (
 	"strings"
+	"text/template"
 
 	"github.com/itchyny/github-migrator/github"
 	"github.com/itchyny/github-migrator/repo"
@@ -13,15 +14,81 @@ type Migrator interface {
 }
 
 // New creates a new Migrator.
-func New(source, target *repo.Repo, userMapping map[string]string) Migrator {
-	return &migrator{source: source, target: target, userMapping: userMapping}
+func New(source, target *repo.Repo, userMapping map[string]string, opts ...MigratorOption) Migrator {
+	m := &migrator{source: source, target: target, userMapping: userMapping}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// MigratorOption is an option of Migrator.
+type MigratorOption func(*migrator)
+
+// MigratorIssuesAPI returns a migrator option to create the issues with the
+// Issues API instead of the import API, which is unavailable on some hosts.
+// The creation dates cannot be set with the Issues API, so the original
+// timestamps are rendered in the headers of the issues and comments.
+func MigratorIssuesAPI() MigratorOption {
+	return func(m *migrator) {
+		m.useIssuesAPI = true
+		m.renderTimestamps = true
+	}
+}
+
+// MigratorReactionUsers returns a migrator option to render the user logins
+// of the reactions, in addition to the counts.
+func MigratorReactionUsers() MigratorOption {
+	return func(m *migrator) {
+		m.reactionUsers = true
+	}
+}
+
+// MigratorSkipReactions returns a migrator option to skip the reactions.
+func MigratorSkipReactions() MigratorOption {
+	return func(m *migrator) {
+		m.skipReactions = true
+	}
 }
 
 type migrator struct {
 	source, target         *repo.Repo
 	userMapping            map[string]string
+	useIssuesAPI           bool
+	renderTimestamps       bool
+	attachments            *attachments
+	redaction              *redaction
+	rewriteRules           RewriteRules
+	reactionUsers          bool
+	skipReactions          bool
+	templatesDir           string
+	mentionPolicy          MentionPolicy
+	mentionAllowlist       map[string]bool
+	labelMapping           LabelMapping
+	milestoneMapping       map[string]string
+	conflictPolicies       ConflictPolicies
+	prune                  Prune
+	renumbering            *Renumbering
+	renumberingFilter      commentFilter
+	issueNumbers           *IssueNumberMap
+	lastTargetIssueNumber  int
+	sourceLabel            string
+	routedIssues           map[int]*routedIssue
+	repoMapping            RepoMapping
+	commitMap              *CommitMap
+	hostLinks              *hostLinks
+	teamMapping            map[string]string
+	deriveTeams            bool
+	targetTeams            map[string]*github.Team
+	templates              *template.Template
+	pendingAssignees       map[int][]string
+	droppedAssignees       []*droppedAssignee
+	droppedAssigneesPath   string
+	validateUsers          bool
+	allowUnmappedUsers     bool
 	sourceRepo, targetRepo *github.Repo
 	commentFilters         commentFilters
+	titleFilters           commentFilters
 	targetMembers          []*github.Member
 	targetProjects         []*github.Project
 	projectByIDs           map[int]*github.Project
@@ -33,22 +100,105 @@ type migrator struct {
 
 // Migrate the repository.
 func (m *migrator) Migrate() (err error) {
+	if err = m.prepare(); err != nil {
+		return err
+	}
+	if m.renumbering != nil {
+		if err = m.loadIssueNumbers(); err != nil {
+			return err
+		}
+	}
+	if err = m.migrateRepo(); err != nil {
+		return err
+	}
+	if err = m.migrateBeforeIssues(); err != nil {
+		return err
+	}
+	if err = m.migrateIssues(); err != nil {
+		return err
+	}
+	return m.migrateAfterIssues()
+}
+
+// prepare fetches the repositories, loads the templates and the teams, sets up
+// the comment filters, and validates the users when specified.
+func (m *migrator) prepare() (err error) {
 	if m.sourceRepo, err = m.source.Get(); err != nil {
 		return err
 	}
 	if m.targetRepo, err = m.target.Get(); err != nil {
 		return err
 	}
-	m.commentFilters = newCommentFilters(
-		newRepoURLFilter(m.sourceRepo, m.targetRepo),
-		newUserMappingFilter(m.userMapping, m.targetRepo),
-	)
-	if m.targetMembers, err = github.MembersToSlice(m.target.ListMembers()); err != nil {
+	if err = m.loadTemplates(); err != nil {
 		return err
 	}
-	if err = m.migrateRepo(); err != nil {
+	if err = m.loadTeams(); err != nil {
+		return err
+	}
+	var filters, titleFilters []commentFilter
+	// the sensitive data should be redacted before anything else
+	if m.redaction != nil {
+		filter, err := m.newRedactionFilter()
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		titleFilters = append(titleFilters, filter)
+	}
+	if m.hostLinks != nil {
+		filters = append(filters, m.newRelativeLinkFilter())
+	}
+	if m.attachments != nil {
+		filter, err := m.newAttachmentFilter()
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+	}
+	if len(m.rewriteRules) > 0 {
+		filter, err := m.newRewriteRulesFilter()
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		titleFilters = append(titleFilters, filter)
+	}
+	if m.commitMap != nil {
+		filters = append(filters, m.newCommitMapFilter())
+	}
+	if m.renumbering != nil {
+		m.renumberingFilter = m.newRenumberingFilter()
+		filters = append(filters, m.renumberingFilter)
+	}
+	if len(m.repoMapping) > 0 {
+		filters = append(filters, m.newRepoMappingFilter())
+	}
+	filters = append(filters, newRepoURLFilter(m.sourceRepo, m.targetRepo))
+	if m.hostLinks != nil {
+		filter, err := m.newHostLinksFilter()
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+	}
+	if m.targetTeams != nil {
+		filters = append(filters, m.newTeamMappingFilter())
+	}
+	filters = append(filters, newUserMappingFilter(m.userMapping, m.targetRepo))
+	if m.mentionPolicy != MentionPolicyKeep {
+		filters = append(filters, m.newMentionFilter())
+	}
+	m.commentFilters = newCommentFilters(filters...)
+	m.titleFilters = newCommentFilters(titleFilters...)
+	if m.targetMembers, err = github.MembersToSlice(m.target.ListMembers()); err != nil {
 		return err
 	}
+	return m.preflightUsers()
+}
+
+// migrateBeforeIssues migrates the labels, projects and milestones, which
+// should be imported before the issues.
+func (m *migrator) migrateBeforeIssues() (err error) {
 	if err = m.migrateLabels(); err != nil {
 		return err
 	}
@@ -65,10 +215,13 @@ func (m *migrator) Migrate() (err error) {
 		m.targetProjects = projects
 	}
 	// milestones should be imported before issues
-	if err = m.migrateMilestones(); err != nil {
-		return err
-	}
-	if err = m.migrateIssues(); err != nil {
+	return m.migrateMilestones()
+}
+
+// migrateAfterIssues migrates the assignees, project cards and hooks, which
+// should be imported after the issues.
+func (m *migrator) migrateAfterIssues() (err error) {
+	if err = m.migrateAssignees(); err != nil {
 		return err
 	}
 	// projects cards should be imported after issues
@@ -78,5 +231,11 @@ func (m *migrator) Migrate() (err error) {
 	if err = m.migrateHooks(); err != nil {
 		return err
 	}
-	return nil
+	if err = m.saveRedactionReport(); err != nil {
+		return err
+	}
+	if err = m.saveAttachmentsReport(); err != nil {
+		return err
+	}
+	return m.saveHostLinksReport()
 }
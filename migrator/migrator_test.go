@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -26,10 +27,12 @@ type testRepo struct {
 	Repo         *github.Repo
 	UpdateRepo   *github.Repo            `json:"update_repo"`
 	Members      []*github.Member        `json:"members"`
+	Teams        []*github.Team          `json:"teams"`
 	UserByNames  map[string]*github.User `json:"users"`
 	Labels       []*github.Label         `json:"labels"`
 	CreateLabels []*github.Label         `json:"create_labels"`
 	UpdateLabels []*github.Label         `json:"update_labels"`
+	DeleteLabels []string                `json:"delete_labels"`
 	Issues       []struct {
 		*github.PullReq
 		Comments       []*github.Comment       `json:"comments"`
@@ -38,9 +41,15 @@ type testRepo struct {
 		Reviews        []*github.Review        `json:"reviews"`
 		ReviewComments []*github.ReviewComment `json:"review_comments"`
 	}
-	Compare  map[string]string
-	Imports  []*github.Import `json:"imports"`
-	Projects []*struct {
+	Compare        map[string]string
+	Reactions      []*testReactions            `json:"reactions"`
+	Imports        []*github.Import            `json:"imports"`
+	CreateIssues   []*github.CreateIssueParams `json:"create_issues"`
+	CreateComments []*testComment              `json:"create_comments"`
+	UpdateIssues   []*testUpdateIssue          `json:"update_issues"`
+	Assignees      []*github.User              `json:"assignees"`
+	AddAssignees   []*testAddAssignees         `json:"add_assignees"`
+	Projects       []*struct {
 		*github.Project
 		Columns []*testProjectColumn `json:"columns"`
 	} `json:"projects"`
@@ -51,11 +60,43 @@ type testRepo struct {
 	Milestones           []*github.Milestone               `json:"milestones"`
 	CreateMilestones     []*github.Milestone               `json:"create_milestones"`
 	UpdateMilestones     []*github.Milestone               `json:"update_milestones"`
+	DeleteMilestones     []int                             `json:"delete_milestones"`
 	Hooks                []*github.Hook                    `json:"hooks"`
 	CreateHooks          []*github.Hook                    `json:"create_hooks"`
 	UpdateHooks          []*github.Hook                    `json:"update_hooks"`
 }
 
+type testComment struct {
+	IssueNumber int    `json:"issue_number"`
+	Body        string `json:"body"`
+}
+
+type testReactions struct {
+	IssueNumber     int                `json:"issue_number"`
+	CommentID       int                `json:"comment_id"`
+	ReviewCommentID int                `json:"review_comment_id"`
+	Reactions       []*github.Reaction `json:"reactions"`
+}
+
+func (r *testRepo) lookupReactions(f func(*testReactions) bool) github.Reactions {
+	for _, rs := range r.Reactions {
+		if f(rs) {
+			return github.ReactionsFromSlice(rs.Reactions)
+		}
+	}
+	panic("unexpected reactions")
+}
+
+type testAddAssignees struct {
+	IssueNumber int      `json:"issue_number"`
+	Assignees   []string `json:"assignees"`
+}
+
+type testUpdateIssue struct {
+	IssueNumber int `json:"issue_number"`
+	*github.UpdateIssueParams
+}
+
 type testProjectColumn struct {
 	*github.ProjectColumn
 	Cards []*github.ProjectCard `json:"cards"`
@@ -82,6 +123,19 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 			assert.True(t, isTarget)
 			return github.MembersFromSlice(r.Members)
 		}),
+		github.MockListTeams(func(string) github.Teams {
+			assert.True(t, isTarget)
+			return github.TeamsFromSlice(r.Teams)
+		}),
+		github.MockGetTeam(func(org, slug string) (*github.Team, error) {
+			assert.True(t, isTarget)
+			for _, team := range r.Teams {
+				if team.Slug == slug {
+					return team, nil
+				}
+			}
+			return nil, fmt.Errorf("team not found: %s/%s", org, slug)
+		}),
 
 		github.MockGetRepo(func(string) (*github.Repo, error) {
 			return r.Repo, nil
@@ -122,6 +176,15 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 				return nil, nil
 			}
 		})(0)),
+		github.MockDeleteLabel((func(i int) func(string, string) error {
+			return func(_, name string) error {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.DeleteLabels), i)
+				assert.Equal(t, r.DeleteLabels[i], name)
+				return nil
+			}
+		})(0)),
 
 		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
 			xs := make([]*github.Issue, len(r.Issues))
@@ -138,8 +201,55 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 			}
 			panic(fmt.Sprintf("unexpected issue number: %d", issueNumber))
 		}),
+		github.MockCreateIssue((func(i int) func(string, *github.CreateIssueParams) (*github.Issue, error) {
+			return func(_ string, params *github.CreateIssueParams) (*github.Issue, error) {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.CreateIssues), i)
+				assert.Equal(t, r.CreateIssues[i], params)
+				number := len(r.Issues) + i + 1
+				return &github.Issue{
+					ID:      number + 100,
+					Number:  number,
+					HTMLURL: fmt.Sprintf("%s/issues/%d", r.Repo.HTMLURL, number),
+				}, nil
+			}
+		})(0)),
+		github.MockUpdateIssue((func(i int) func(string, int, *github.UpdateIssueParams) (*github.Issue, error) {
+			return func(_ string, issueNumber int, params *github.UpdateIssueParams) (*github.Issue, error) {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.UpdateIssues), i)
+				assert.Equal(t, r.UpdateIssues[i].IssueNumber, issueNumber)
+				assert.Equal(t, r.UpdateIssues[i].UpdateIssueParams, params)
+				return nil, nil
+			}
+		})(0)),
+		github.MockListAssignees(func(string) github.Users {
+			assert.True(t, isTarget)
+			return github.UsersFromSlice(r.Assignees)
+		}),
+		github.MockAddAssignees((func(i int) func(string, int, []string) error {
+			return func(_ string, issueNumber int, assignees []string) error {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.AddAssignees), i)
+				assert.Equal(t, r.AddAssignees[i].IssueNumber, issueNumber)
+				assert.Equal(t, r.AddAssignees[i].Assignees, assignees)
+				return nil
+			}
+		})(0)),
+		github.MockCreateComment((func(i int) func(string, int, string) (*github.Comment, error) {
+			return func(_ string, issueNumber int, body string) (*github.Comment, error) {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.CreateComments), i)
+				assert.Equal(t, r.CreateComments[i].IssueNumber, issueNumber)
+				assert.Equal(t, r.CreateComments[i].Body, body)
+				return nil, nil
+			}
+		})(0)),
 		github.MockListComments(func(_ string, issueNumber int) github.Comments {
-			assert.True(t, !isTarget)
 			for _, s := range r.Issues {
 				if s.Issue.Number == issueNumber {
 					return github.CommentsFromSlice(s.Comments)
@@ -156,6 +266,24 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 			}
 			panic(fmt.Sprintf("unexpected issue number: %d", issueNumber))
 		}),
+		github.MockListIssueReactions(func(_ string, issueNumber int) github.Reactions {
+			assert.True(t, !isTarget)
+			return r.lookupReactions(func(rs *testReactions) bool {
+				return rs.IssueNumber == issueNumber
+			})
+		}),
+		github.MockListCommentReactions(func(_ string, commentID int) github.Reactions {
+			assert.True(t, !isTarget)
+			return r.lookupReactions(func(rs *testReactions) bool {
+				return rs.CommentID == commentID
+			})
+		}),
+		github.MockListReviewCommentReactions(func(_ string, commentID int) github.Reactions {
+			assert.True(t, !isTarget)
+			return r.lookupReactions(func(rs *testReactions) bool {
+				return rs.ReviewCommentID == commentID
+			})
+		}),
 
 		github.MockGetPullReq(func(_ string, pullNumber int) (*github.PullReq, error) {
 			assert.True(t, !isTarget)
@@ -316,7 +444,11 @@ func (r *testRepo) build(t *testing.T, isTarget bool) *repo.Repo {
 			}
 		})(0)),
 		github.MockDeleteMilestone((func(i int) func(string, int) error {
-			return func(string, int) error {
+			return func(_ string, milestoneNumber int) error {
+				defer func() { i++ }()
+				assert.True(t, isTarget)
+				require.Greater(t, len(r.DeleteMilestones), i)
+				assert.Equal(t, r.DeleteMilestones[i], milestoneNumber)
 				return nil
 			}
 		})(0)),
@@ -378,10 +510,24 @@ func TestMigratorMigrate(t *testing.T) {
 	defer f.Close()
 
 	var testCases []struct {
-		Name        string            `json:"name"`
-		Source      *testRepo         `json:"source"`
-		Target      *testRepo         `json:"target"`
-		UserMapping map[string]string `json:"user_mapping"`
+		Name             string            `json:"name"`
+		Source           *testRepo         `json:"source"`
+		Target           *testRepo         `json:"target"`
+		UserMapping      map[string]string `json:"user_mapping"`
+		IssuesAPI        bool              `json:"issues_api"`
+		ReactionUsers    bool              `json:"reaction_users"`
+		RewriteRules     RewriteRules      `json:"rewrite_rules"`
+		Redaction        RedactionPatterns `json:"redaction_patterns"`
+		MentionPolicy    string            `json:"mention_policy"`
+		MentionAllow     []string          `json:"mention_allowlist"`
+		TeamMapping      map[string]string `json:"team_mapping"`
+		DeriveTeams      bool              `json:"derive_teams"`
+		LabelMapping     LabelMapping      `json:"label_mapping"`
+		MilestoneMapping map[string]string `json:"milestone_mapping"`
+		Conflicts        *ConflictPolicies `json:"conflict_policies"`
+		Prune            *Prune            `json:"prune"`
+		Renumbering      *Renumbering      `json:"renumbering"`
+		RepoMapping      RepoMapping       `json:"repo_mapping"`
 	}
 	require.NoError(t, decodeYAML(f, &testCases))
 
@@ -389,7 +535,49 @@ func TestMigratorMigrate(t *testing.T) {
 		t.Run(tc.Name, func(t *testing.T) {
 			source := tc.Source.build(t, false)
 			target := tc.Target.build(t, true)
-			migrator := New(source, target, tc.UserMapping)
+			var opts []MigratorOption
+			if tc.IssuesAPI {
+				opts = append(opts, MigratorIssuesAPI())
+			}
+			if tc.ReactionUsers {
+				opts = append(opts, MigratorReactionUsers())
+			}
+			if len(tc.RewriteRules) > 0 {
+				opts = append(opts, MigratorRewriteRules(tc.RewriteRules))
+			}
+			if len(tc.Redaction) > 0 {
+				opts = append(opts, MigratorRedaction(tc.Redaction, ""))
+			}
+			if tc.MentionPolicy != "" {
+				policy, err := ParseMentionPolicy(tc.MentionPolicy)
+				require.NoError(t, err)
+				opts = append(opts, MigratorMentionPolicy(policy, tc.MentionAllow))
+			}
+			if len(tc.TeamMapping) > 0 || tc.DeriveTeams {
+				opts = append(opts, MigratorTeamMapping(tc.TeamMapping, tc.DeriveTeams))
+			}
+			if len(tc.LabelMapping) > 0 {
+				require.NoError(t, tc.LabelMapping.Validate())
+				opts = append(opts, MigratorLabelMapping(tc.LabelMapping))
+			}
+			if len(tc.MilestoneMapping) > 0 {
+				opts = append(opts, MigratorMilestoneMapping(tc.MilestoneMapping))
+			}
+			if tc.Conflicts != nil {
+				opts = append(opts, MigratorConflictPolicies(*tc.Conflicts))
+			}
+			if tc.Prune != nil {
+				opts = append(opts, MigratorPrune(*tc.Prune))
+			}
+			if len(tc.RepoMapping) > 0 {
+				require.NoError(t, tc.RepoMapping.Load())
+				opts = append(opts, MigratorRepoMapping(tc.RepoMapping))
+			}
+			if tc.Renumbering != nil {
+				tc.Renumbering.MapFile = filepath.Join(t.TempDir(), "issue-numbers.json")
+				opts = append(opts, MigratorRenumbering(*tc.Renumbering))
+			}
+			migrator := New(source, target, tc.UserMapping, opts...)
 			assert.Nil(t, migrator.Migrate())
 		})
 	}
@@ -32,7 +32,12 @@ func (m *migrator) migrateMilestones() error {
 	}
 	var deletedMilestones []int
 	for _, l := range sourceMilestones {
-		fmt.Printf("[=>] migrating a milestone: %s\n", l.Title)
+		title := m.lookupMilestoneTitle(l.Title)
+		if title != l.Title {
+			fmt.Printf("[=>] migrating a milestone: %s => %s\n", l.Title, title)
+		} else {
+			fmt.Printf("[=>] migrating a milestone: %s\n", l.Title)
+		}
 		for l.Number > largestMilestoneNumber+1 {
 			n, err := m.target.CreateMilestone(&github.CreateMilestoneParams{
 				Title: fmt.Sprintf("[Deleted milestone %d]", largestMilestoneNumber+1), // must be unique
@@ -44,27 +49,25 @@ func (m *migrator) migrateMilestones() error {
 			largestMilestoneNumber = n.Number
 			deletedMilestones = append(deletedMilestones, n.Number)
 		}
-		n := lookupMilestone(targetMilestones, l)
+		n := lookupMilestone(targetMilestones, title)
 		if n == nil {
-			fmt.Printf("[>>] creating a new milestone: %s\n", l.Title)
+			fmt.Printf("[>>] creating a new milestone: %s\n", title)
 			if n, err = m.target.CreateMilestone(&github.CreateMilestoneParams{
-				Title: l.Title, Description: l.Description,
+				Title: title, Description: l.Description,
 				State: l.State, DueOn: l.DueOn,
 			}); err != nil {
 				return err
 			}
 			largestMilestoneNumber = n.Number
+			targetMilestones = append(targetMilestones, n)
+			continue
 		}
-		if l.Description != n.Description || l.State != n.State || normalizeTimeToPST(l.DueOn) != normalizeTimeToPST(n.DueOn) {
-			fmt.Printf("[|>] updating an existing milestone: %s\n", l.Title)
-			if _, err = m.target.UpdateMilestone(n.Number, &github.UpdateMilestoneParams{
-				Title:       l.Title,
-				Description: l.Description,
-				State:       l.State,
-				DueOn:       l.DueOn,
-			}); err != nil {
-				return err
-			}
+		if title != l.Title {
+			fmt.Printf("[--] skipping: %s (attached to the existing milestone)\n", title)
+			continue
+		}
+		if err := m.updateMilestone(l, n); err != nil {
+			return err
 		}
 	}
 	for _, number := range deletedMilestones {
@@ -72,6 +75,9 @@ func (m *migrator) migrateMilestones() error {
 			return err
 		}
 	}
+	if err := m.pruneMilestones(targetMilestones, sourceMilestones); err != nil {
+		return err
+	}
 	targetMilestones, err = github.MilestonesToSlice(
 		m.target.ListMilestones(&github.ListMilestonesParams{
 			State: github.ListMilestonesParamStateAll,
@@ -87,9 +93,55 @@ func (m *migrator) migrateMilestones() error {
 	return nil
 }
 
-func lookupMilestone(ps []*github.Milestone, l *github.Milestone) *github.Milestone {
+func (m *migrator) updateMilestone(l, n *github.Milestone) error {
+	var c conflict
+	c.add("description", l.Description != n.Description, n.Description == "")
+	c.add("state", l.State != n.State, false)
+	c.add("due date", normalizeTimeToPST(l.DueOn) != normalizeTimeToPST(n.DueOn), n.DueOn == "")
+	update, err := c.resolve(m.conflictPolicies.Milestones, "milestone", l.Title)
+	if err != nil || update == nil {
+		return err
+	}
+	params := &github.UpdateMilestoneParams{
+		Title:       n.Title,
+		Description: n.Description,
+		State:       n.State,
+		DueOn:       n.DueOn,
+	}
+	if update["description"] {
+		params.Description = l.Description
+	}
+	if update["state"] {
+		params.State = l.State
+	}
+	if update["due date"] {
+		params.DueOn = l.DueOn
+	}
+	_, err = m.target.UpdateMilestone(n.Number, params)
+	return err
+}
+
+// MigratorMilestoneMapping returns a migrator option to map the milestones of
+// the source to the milestones on the target by the titles. The issues and the
+// milestone events are attached to the mapped milestones, which are created
+// unless they exist on the target.
+func MigratorMilestoneMapping(mapping map[string]string) MigratorOption {
+	return func(m *migrator) {
+		m.milestoneMapping = mapping
+	}
+}
+
+// lookupMilestoneTitle returns the milestone title on the target.
+func (m *migrator) lookupMilestoneTitle(title string) string {
+	if t, ok := m.milestoneMapping[title]; ok && t != "" {
+		return t
+	}
+	return title
+}
+
+func lookupMilestone(ps []*github.Milestone, title string) *github.Milestone {
 	for _, n := range ps {
-		if l.Title == n.Title {
+		if title == n.Title {
 			return n
 		}
 	}
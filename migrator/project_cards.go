@@ -28,6 +28,7 @@ func (m *migrator) migrateProjectCards() error {
 	}
 	for _, p := range sourceProjects {
 		fmt.Printf("[=>] migrating cards in a project: %s\n", p.Name)
+		m.setLocation(p.HTMLURL)
 		q := lookupProject(targetProjects, p)
 		if q == nil {
 			return fmt.Errorf("project not found: %s", p.Name)
@@ -82,13 +83,17 @@ func (m *migrator) migrateProjectCardsInColumn(sourceID, targetID int) error {
 	reverseProjectCards(sourceCards)
 	for _, c := range sourceCards {
 		fmt.Printf("[=>] migrating a card: %s\n", m.getCardInfo(c))
-		if lookupProjectCard(targetCards, c) != nil {
+		if r, ok := m.routedIssues[c.GetIssueNumber()]; ok {
+			fmt.Printf("[--] skipping: %s (migrated to %s)\n", m.getCardInfo(c), r.repo.FullName)
+			continue
+		}
+		if lookupProjectCard(targetCards, c, m.getCardIssueNumber(c)) != nil {
 			fmt.Printf("[--] skipping: %s (already exists)\n", m.getCardInfo(c))
 			continue
 		}
 		fmt.Printf("[>>] creating a new card: %s\n", m.getCardInfo(c))
 		var params *github.CreateProjectCardParams
-		if issueNumber := c.GetIssueNumber(); issueNumber > 0 {
+		if issueNumber := m.getCardIssueNumber(c); issueNumber > 0 {
 			id, err := m.getTargetIssueID(issueNumber)
 			if err != nil {
 				return err
@@ -110,9 +115,9 @@ func (m *migrator) migrateProjectCardsInColumn(sourceID, targetID int) error {
 	return nil
 }
 
-func lookupProjectCard(cs []*github.ProjectCard, c *github.ProjectCard) *github.ProjectCard {
+func lookupProjectCard(cs []*github.ProjectCard, c *github.ProjectCard, issueNumber int) *github.ProjectCard {
 	for _, d := range cs {
-		if c.Note != "" && c.Note == d.Note || c.GetIssueNumber() == d.GetIssueNumber() {
+		if c.Note != "" && c.Note == d.Note || issueNumber == d.GetIssueNumber() {
 			return d
 		}
 	}
@@ -125,8 +130,19 @@ func reverseProjectCards(cs []*github.ProjectCard) {
 	}
 }
 
-func (m *migrator) getCardInfo(c *github.ProjectCard) string {
+// getCardIssueNumber returns the number of the issue of the card on the target.
+func (m *migrator) getCardIssueNumber(c *github.ProjectCard) int {
 	if issueNumber := c.GetIssueNumber(); issueNumber > 0 {
+		return m.lookupIssueNumber(issueNumber)
+	}
+	return 0
+}
+
+func (m *migrator) getCardInfo(c *github.ProjectCard) string {
+	if r, ok := m.routedIssues[c.GetIssueNumber()]; ok {
+		return fmt.Sprintf("%s/issues/%d", r.repo.FullName, r.number)
+	}
+	if issueNumber := m.getCardIssueNumber(c); issueNumber > 0 {
 		return fmt.Sprintf("%s/issues/%d", m.targetRepo.FullName, issueNumber)
 	}
 	xs := strings.Split(c.Note, "\n")
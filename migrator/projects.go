@@ -30,6 +30,7 @@ func (m *migrator) migrateProjects() error {
 	}
 	for _, p := range sourceProjects {
 		fmt.Printf("[=>] migrating a project: %s\n", p.Name)
+		m.setLocation(p.HTMLURL)
 		for p.Number > largestProjectNumber+1 {
 			q, err := m.target.CreateProject(&github.CreateProjectParams{
 				Name: "[Deleted project]",
@@ -52,15 +53,16 @@ func (m *migrator) migrateProjects() error {
 				return err
 			}
 			largestProjectNumber = q.Number
-		}
-		if body != q.Body || p.State != q.State {
-			fmt.Printf("[|>] updating an existing project: %s\n", p.Name)
-			if q, err = m.target.UpdateProject(q.ID, &github.UpdateProjectParams{
-				// Do not update name.
-				Body: body, State: p.State,
-			}); err != nil {
-				return err
+			if p.State != q.State {
+				fmt.Printf("[|>] updating the state of the project: %s\n", p.Name)
+				if q, err = m.target.UpdateProject(q.ID, &github.UpdateProjectParams{
+					Body: body, State: p.State,
+				}); err != nil {
+					return err
+				}
 			}
+		} else if q, err = m.updateProject(p, q, body); err != nil {
+			return err
 		}
 		if err := m.migrateProjectColumns(p.ID, q.ID); err != nil {
 			return err
@@ -69,6 +71,27 @@ func (m *migrator) migrateProjects() error {
 	return nil
 }
 
+func (m *migrator) updateProject(p, q *github.Project, body string) (*github.Project, error) {
+	var c conflict
+	c.add("body", body != q.Body, q.Body == "")
+	c.add("state", p.State != q.State, false)
+	update, err := c.resolve(m.conflictPolicies.Projects, "project", p.Name)
+	if err != nil || update == nil {
+		return q, err
+	}
+	params := &github.UpdateProjectParams{
+		// Do not update name.
+		Body: q.Body, State: q.State,
+	}
+	if update["body"] {
+		params.Body = body
+	}
+	if update["state"] {
+		params.State = p.State
+	}
+	return m.target.UpdateProject(q.ID, params)
+}
+
 func (m *migrator) getProject(id int) (*github.Project, error) {
 	if p, ok := m.projectByIDs[id]; ok {
 		return p, nil
@@ -0,0 +1,132 @@
+package migrator
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// Prune is the setting to delete the labels and milestones on the target
+// which do not exist in the source. The labels and milestones in the allowlist
+// (by the names and the titles) are kept.
+type Prune struct {
+	Labels     bool
+	Milestones bool
+	Allowlist  []string
+}
+
+// MigratorPrune returns a migrator option to delete the labels and milestones
+// on the target which do not exist in the source, unless they are used by the
+// issues on the target.
+func MigratorPrune(prune Prune) MigratorOption {
+	return func(m *migrator) {
+		m.prune = prune
+	}
+}
+
+func (m *migrator) isPruneAllowed(name string) bool {
+	for _, x := range m.prune.Allowlist {
+		if strings.EqualFold(x, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneLabels deletes the labels on the target which are not migrated.
+func (m *migrator) pruneLabels(targetLabels []*github.Label, migratedLabels []string) error {
+	if !m.prune.Labels {
+		return nil
+	}
+	for _, l := range targetLabels {
+		if containsLabel(migratedLabels, l.Name) {
+			continue
+		}
+		if m.isPruneAllowed(l.Name) {
+			fmt.Printf("[--] keeping a label: %s (in the allowlist)\n", l.Name)
+			continue
+		}
+		// the labels filter of the issues API splits the names by the commas,
+		// so the issues with the label cannot be listed reliably
+		if strings.Contains(l.Name, ",") {
+			fmt.Printf("[--] keeping a label: %s (cannot check the issues with the label containing a comma)\n", l.Name)
+			continue
+		}
+		numbers, err := listIssueNumbers(m.target.ListIssuesByLabel(l.Name), func(issue *github.Issue) bool {
+			for _, x := range issue.Labels {
+				if strings.EqualFold(x.Name, l.Name) {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+		if len(numbers) > 0 {
+			fmt.Printf("[--] keeping a label: %s (used by %s)\n", l.Name, formatIssueNumbers(numbers))
+			continue
+		}
+		fmt.Printf("[!!] deleting a label: %s (not in the source)\n", l.Name)
+		if err := m.target.DeleteLabel(l.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneMilestones deletes the milestones on the target which do not exist in
+// the source.
+func (m *migrator) pruneMilestones(targetMilestones, sourceMilestones []*github.Milestone) error {
+	if !m.prune.Milestones {
+		return nil
+	}
+	migratedMilestones := make(map[string]bool, len(sourceMilestones))
+	for _, l := range sourceMilestones {
+		migratedMilestones[m.lookupMilestoneTitle(l.Title)] = true
+	}
+	for _, l := range targetMilestones {
+		if migratedMilestones[l.Title] {
+			continue
+		}
+		if m.isPruneAllowed(l.Title) {
+			fmt.Printf("[--] keeping a milestone: %s (in the allowlist)\n", l.Title)
+			continue
+		}
+		numbers, err := listIssueNumbers(m.target.ListIssuesByMilestone(l.Number), func(issue *github.Issue) bool {
+			return issue.Milestone != nil && issue.Milestone.Number == l.Number
+		})
+		if err != nil {
+			return err
+		}
+		if len(numbers) > 0 {
+			fmt.Printf("[--] keeping a milestone: %s (used by %s)\n", l.Title, formatIssueNumbers(numbers))
+			continue
+		}
+		fmt.Printf("[!!] deleting a milestone: %s (not in the source)\n", l.Title)
+		if err := m.target.DeleteMilestone(l.Number); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listIssueNumbers lists the numbers of the issues matching the condition,
+// which confirms the filters of the issues.
+func listIssueNumbers(issues github.Issues, f func(*github.Issue) bool) ([]int, error) {
+	var numbers []int
+	for {
+		issue, err := issues.Next()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			return numbers, nil
+		}
+		if f(issue) {
+			numbers = append(numbers, issue.Number)
+		}
+	}
+}
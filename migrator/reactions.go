@@ -0,0 +1,91 @@
+package migrator
+
+import "github.com/itchyny/github-migrator/github"
+
+type reactions struct {
+	issue          []*github.Reaction
+	comments       map[int][]*github.Reaction
+	reviewComments map[int][]*github.Reaction
+}
+
+// listReactions lists the reactions of the issue and comments. The reactions
+// are fetched only when the summary reports some, to avoid the requests for
+// each comment.
+func (m *migrator) listReactions(
+	issue *github.Issue, comments []*github.Comment, reviewComments []*github.ReviewComment,
+) (reactions, error) {
+	var rs reactions
+	if m.skipReactions {
+		return rs, nil
+	}
+	var err error
+	if hasReactions(issue.Reactions) {
+		if rs.issue, err = github.ReactionsToSlice(m.source.ListIssueReactions(issue.Number)); err != nil {
+			return rs, err
+		}
+	}
+	for _, c := range comments {
+		if hasReactions(c.Reactions) {
+			if rs.comments == nil {
+				rs.comments = make(map[int][]*github.Reaction)
+			}
+			if rs.comments[c.ID], err = github.ReactionsToSlice(m.source.ListCommentReactions(c.ID)); err != nil {
+				return rs, err
+			}
+		}
+	}
+	for _, c := range reviewComments {
+		if hasReactions(c.Reactions) {
+			if rs.reviewComments == nil {
+				rs.reviewComments = make(map[int][]*github.Reaction)
+			}
+			if rs.reviewComments[c.ID], err = github.ReactionsToSlice(m.source.ListReviewCommentReactions(c.ID)); err != nil {
+				return rs, err
+			}
+		}
+	}
+	return rs, nil
+}
+
+func hasReactions(s *github.ReactionSummary) bool {
+	return s != nil && s.TotalCount > 0
+}
+
+var reactionEmojis = []struct {
+	content, emoji string
+}{
+	{"+1", "👍"},
+	{"-1", "👎"},
+	{"laugh", "😄"},
+	{"hooray", "🎉"},
+	{"confused", "😕"},
+	{"heart", "❤️"},
+	{"rocket", "🚀"},
+	{"eyes", "👀"},
+}
+
+// buildReactions summarizes the reactions by the contents, which are rendered
+// like "👍 2 · 🎉 1" (with the user logins if configured).
+func (b *builder) buildReactions(rs []*github.Reaction) reactionsData {
+	data := reactionsData{ShowUsers: b.reactionUsers}
+	if len(rs) == 0 {
+		return data
+	}
+	usersByContent := make(map[string][]string)
+	for _, r := range rs {
+		usersByContent[r.Content] = append(usersByContent[r.Content], b.getUserLogin(r.User))
+	}
+	for _, e := range reactionEmojis {
+		users := usersByContent[e.content]
+		if len(users) == 0 {
+			continue
+		}
+		data.Reactions = append(data.Reactions, &reactionData{
+			Content: e.content,
+			Emoji:   e.emoji,
+			Count:   len(users),
+			Users:   users,
+		})
+	}
+	return data
+}
@@ -0,0 +1,181 @@
+package migrator
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RedactionPattern is a pattern of the sensitive data to be redacted.
+type RedactionPattern struct {
+	Name   string
+	Regexp string
+	re     *regexp.Regexp
+}
+
+// RedactionPatterns is the list of redaction patterns.
+type RedactionPatterns []*RedactionPattern
+
+// Compile validates the patterns and compiles the regular expressions.
+func (ps RedactionPatterns) Compile() error {
+	for i, p := range ps {
+		if p.Name == "" || p.Regexp == "" {
+			return fmt.Errorf("redaction pattern %d: specify name and regexp", i+1)
+		}
+		re, err := regexp.Compile(p.Regexp)
+		if err != nil {
+			return fmt.Errorf("redaction pattern %d: %w", i+1, err)
+		}
+		p.re = re
+	}
+	return nil
+}
+
+// defaultRedactionPatterns detect the common credential formats.
+var defaultRedactionPatterns = RedactionPatterns{
+	{
+		Name:   "private key",
+		Regexp: `-----BEGIN [A-Z ]*PRIVATE KEY( BLOCK)?-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY( BLOCK)?-----`,
+	},
+	{
+		Name:   "GitHub token",
+		Regexp: `\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`,
+	},
+	{
+		Name:   "AWS access key",
+		Regexp: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+	},
+	{
+		Name:   "AWS secret key",
+		Regexp: `(?i)\baws_?secret_?access_?key\b["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`,
+	},
+	{
+		Name:   "JWT",
+		Regexp: `\beyJ[A-Za-z0-9_-]{4,}\.eyJ[A-Za-z0-9_-]{4,}\.[A-Za-z0-9_-]+`,
+	},
+}
+
+// MigratorRedaction returns a migrator option to redact the sensitive data
+// (credentials detected by the default patterns, and the additional patterns)
+// in the issues, comments, commit messages and diffs. The report of the
+// redactions (what was redacted and where, without the redacted text) is
+// written to the report file, merged with the report of the previous runs.
+func MigratorRedaction(patterns RedactionPatterns, reportPath string) MigratorOption {
+	return func(m *migrator) {
+		m.redaction = &redaction{
+			patterns:   append(append(RedactionPatterns{}, defaultRedactionPatterns...), patterns...),
+			reportPath: reportPath,
+		}
+	}
+}
+
+type redaction struct {
+	patterns   RedactionPatterns
+	reportPath string
+	location   string
+	report     []*redactionReport
+	previous   []*redactionReport
+	loaded     bool
+}
+
+type redactionReport struct {
+	Location string `json:"location"`
+	Pattern  string `json:"pattern"`
+	Preview  string `json:"preview"`
+	Length   int    `json:"length"`
+}
+
+func (r *redaction) apply(src string) string {
+	for _, p := range r.patterns {
+		src = p.re.ReplaceAllStringFunc(src, func(s string) string {
+			fmt.Printf("[!!] redacting: %s (%s)\n", p.Name, r.location)
+			r.report = append(r.report, &redactionReport{
+				Location: r.location,
+				Pattern:  p.Name,
+				Preview:  redactionPreview(s),
+				Length:   len(s),
+			})
+			return "[REDACTED " + p.Name + "]"
+		})
+	}
+	return src
+}
+
+// redactionPreview shows only the first few characters of the redacted text,
+// so that the reviewer can identify the credential to revoke.
+func redactionPreview(s string) string {
+	if len(s) <= 12 {
+		return "..."
+	}
+	return s[:4] + "..."
+}
+
+// loadReport loads the report of the previous runs, which has the redactions
+// in the issues skipped on this run.
+func (r *redaction) loadReport() error {
+	if r.reportPath == "" || r.loaded {
+		return nil
+	}
+	r.loaded = true
+	return loadReport(r.reportPath, &r.previous)
+}
+
+// saveReport writes the report, replacing the entries of the previous runs
+// with the same location and pattern.
+func (r *redaction) saveReport() error {
+	if r.reportPath == "" {
+		return nil
+	}
+	type key struct{ location, pattern string }
+	keys := make(map[key]bool, len(r.report))
+	for _, x := range r.report {
+		keys[key{x.Location, x.Pattern}] = true
+	}
+	report := []*redactionReport{}
+	for _, x := range r.previous {
+		if !keys[key{x.Location, x.Pattern}] {
+			report = append(report, x)
+		}
+	}
+	return saveReport(r.reportPath, append(report, r.report...))
+}
+
+func (m *migrator) newRedactionFilter() (commentFilter, error) {
+	if err := m.redaction.patterns.Compile(); err != nil {
+		return nil, err
+	}
+	if err := m.redaction.loadReport(); err != nil {
+		return nil, err
+	}
+	return commentFilter(m.redaction.apply), nil
+}
+
+// setLocation sets the location (url of the source) reported with the
+// redactions, the unmapped links and the failed attachments in the following
+// text.
+func (m *migrator) setLocation(location string) {
+	if m.redaction != nil {
+		m.redaction.location = location
+	}
+	if m.hostLinks != nil {
+		m.hostLinks.location = location
+	}
+	if m.attachments != nil {
+		m.attachments.location = location
+	}
+}
+
+// redact redacts the text which is not passed through the comment filters,
+// like the diffs of the pull requests.
+func (m *migrator) redact(src string) string {
+	if m.redaction == nil {
+		return src
+	}
+	return m.redaction.apply(src)
+}
+
+func (m *migrator) saveRedactionReport() error {
+	if m.redaction == nil {
+		return nil
+	}
+	return m.redaction.saveReport()
+}
@@ -0,0 +1,273 @@
+package migrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// Renumbering is the setting to renumber the issues on the target, which
+// already has issues (or pull requests). The source issue N becomes the target
+// issue N+Offset, or the next free number when Offset is zero. The number map
+// is persisted to MapFile, which is used by the later runs.
+type Renumbering struct {
+	Offset  int
+	MapFile string
+}
+
+// MigratorRenumbering returns a migrator option to renumber the issues, and
+// rewrite the references to the issues in the bodies, comments, commit
+// messages, titles and events with the issue number map.
+func MigratorRenumbering(renumbering Renumbering) MigratorOption {
+	return func(m *migrator) {
+		m.renumbering = &renumbering
+	}
+}
+
+// IssueNumberMap maps the issue numbers of the source to the target.
+type IssueNumberMap struct {
+	Source string      `json:"source"`
+	Target string      `json:"target"`
+	Issues map[int]int `json:"issues"`
+}
+
+// LoadIssueNumberMap loads the issue number map from the JSON file.
+func LoadIssueNumberMap(path string) (*IssueNumberMap, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var nm IssueNumberMap
+	if err := json.Unmarshal(bs, &nm); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if nm.Issues == nil {
+		nm.Issues = make(map[int]int)
+	}
+	return &nm, nil
+}
+
+// Save saves the issue number map to the JSON file.
+func (nm *IssueNumberMap) Save(path string) error {
+	bs, err := json.MarshalIndent(nm, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0o644)
+}
+
+// loadIssueNumbers loads the issue number map and assigns the target numbers
+// to the source issues which are not in the map yet. The target numbers are
+// assigned before the migration, so that the references to the issues which
+// are not migrated yet can be rewritten.
+func (m *migrator) loadIssueNumbers() error {
+	nm, err := m.openIssueNumberMap()
+	if err != nil {
+		return err
+	}
+	sourceNumbers, err := listIssueNumbers(m.source.ListIssues(), func(*github.Issue) bool {
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if err := m.loadLastTargetIssueNumber(); err != nil {
+		return err
+	}
+	next := nextIssueNumber(m.lastTargetIssueNumber, nm)
+	sort.Ints(sourceNumbers)
+	var count int
+	for _, n := range sourceNumbers {
+		if _, ok := nm.Issues[n]; ok {
+			continue
+		}
+		if m.renumbering.Offset > 0 {
+			if n+m.renumbering.Offset < next {
+				return fmt.Errorf("cannot renumber %s/issues/%d to #%d: the target has issues up to #%d (increase the offset)",
+					m.sourceRepo.HTMLURL, n, n+m.renumbering.Offset, next-1)
+			}
+			nm.Issues[n] = n + m.renumbering.Offset
+		} else {
+			nm.Issues[n] = next
+			next++
+		}
+		count++
+	}
+	return m.saveIssueNumberMap(nm, count)
+}
+
+// openIssueNumberMap loads the issue number map, or creates a new one when the
+// file does not exist.
+func (m *migrator) openIssueNumberMap() (*IssueNumberMap, error) {
+	nm, err := LoadIssueNumberMap(m.renumbering.MapFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+		nm = &IssueNumberMap{Issues: make(map[int]int)}
+	}
+	if nm.Source == "" {
+		nm.Source, nm.Target = m.sourceRepo.FullName, m.targetRepo.FullName
+	} else if nm.Source != m.sourceRepo.FullName || nm.Target != m.targetRepo.FullName {
+		return nil, fmt.Errorf("%s: the issue number map is for %s => %s",
+			m.renumbering.MapFile, nm.Source, nm.Target)
+	}
+	return nm, nil
+}
+
+// saveIssueNumberMap saves the issue number map, which is used by the migrator.
+func (m *migrator) saveIssueNumberMap(nm *IssueNumberMap, count int) error {
+	if err := nm.Save(m.renumbering.MapFile); err != nil {
+		return err
+	}
+	fmt.Printf("[<>] saved the issue number map to %s (%s, %d new)\n",
+		m.renumbering.MapFile, plural(len(nm.Issues), "issue"), count)
+	m.issueNumbers = nm
+	return nil
+}
+
+// loadLastTargetIssueNumber finds the largest issue number on the target.
+func (m *migrator) loadLastTargetIssueNumber() error {
+	targetNumbers, err := listIssueNumbers(m.target.ListIssues(), func(*github.Issue) bool {
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for _, n := range targetNumbers {
+		if m.lastTargetIssueNumber < n {
+			m.lastTargetIssueNumber = n
+		}
+	}
+	return nil
+}
+
+// nextIssueNumber returns the next free number on the target, which is not
+// used by the issues on the target nor assigned in the issue number maps.
+func nextIssueNumber(last int, nms ...*IssueNumberMap) int {
+	next := last + 1
+	for _, nm := range nms {
+		for _, n := range nm.Issues {
+			if next <= n {
+				next = n + 1
+			}
+		}
+	}
+	return next
+}
+
+// lookupIssueNumber returns the issue number on the target.
+func (m *migrator) lookupIssueNumber(number int) int {
+	if m.renumbering == nil {
+		return number
+	}
+	if n, ok := m.issueNumbers.Issues[number]; ok {
+		return n
+	}
+	return number + m.renumbering.Offset
+}
+
+// reserveIssueNumbers creates the placeholder issues on the target up to the
+// offset, since the issue numbers cannot be skipped.
+func (m *migrator) reserveIssueNumbers() error {
+	if m.renumbering == nil {
+		return nil
+	}
+	for n := m.lastTargetIssueNumber + 1; n <= m.renumbering.Offset; n++ {
+		issue := &github.Issue{
+			Number:  n,
+			HTMLURL: fmt.Sprintf("%s/issues/%d", m.targetRepo.HTMLURL, n),
+		}
+		fmt.Printf("[>>] creating a new issue: %s (reserved for the offset)\n", issue.HTMLURL)
+		result, err := m.importIssue(n, &github.Import{
+			Issue: &github.ImportIssue{
+				Title:  "[Reserved issue]",
+				Body:   fmt.Sprintf("This issue number is reserved for the migration from %s.", m.sourceRepo.FullName),
+				Closed: true,
+			},
+			Comments: []*github.ImportComment{},
+		})
+		if err != nil {
+			return err
+		}
+		if result != nil {
+			if err := m.waitImportIssue(result.ID, issue); err != nil {
+				return fmt.Errorf("importing %s failed: %w", issue.HTMLURL, err)
+			}
+		}
+		m.lastTargetIssueNumber = n
+	}
+	return nil
+}
+
+// newRenumberingFilter creates a filter to rewrite the references to the
+// source issues; the issue URLs, owner/repo#N and #N. The references to the
+// issues migrated to the other repositories are rewritten to owner/repo#N.
+func (m *migrator) newRenumberingFilter() commentFilter {
+	urlPattern := regexp.MustCompile(
+		regexp.QuoteMeta(m.sourceRepo.HTMLURL) + `/(issues|pull)/(\d+)\b`,
+	)
+	repoPattern := regexp.MustCompile(
+		`\b` + regexp.QuoteMeta(m.sourceRepo.FullName) + `#(\d+)\b`,
+	)
+	numberPattern := regexp.MustCompile(`(^|[^\w&/#])#(\d+)\b`)
+	renumber := func(s string) (*github.Repo, string) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, s
+		}
+		if r, ok := m.routedIssues[n]; ok {
+			return r.repo, strconv.Itoa(r.number)
+		}
+		return nil, strconv.Itoa(m.lookupIssueNumber(n))
+	}
+	return commentFilter(func(src string) string {
+		src = urlPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := urlPattern.FindStringSubmatch(s)
+			r, n := renumber(xs[2])
+			if r != nil {
+				return r.HTMLURL + "/" + xs[1] + "/" + n
+			}
+			return m.sourceRepo.HTMLURL + "/" + xs[1] + "/" + n
+		})
+		src = repoPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := repoPattern.FindStringSubmatch(s)
+			r, n := renumber(xs[1])
+			if r != nil {
+				return r.FullName + "#" + n
+			}
+			return m.targetRepo.FullName + "#" + n
+		})
+		return numberPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := numberPattern.FindStringSubmatch(s)
+			r, n := renumber(xs[2])
+			if r != nil {
+				return xs[1] + r.FullName + "#" + n
+			}
+			return xs[1] + "#" + n
+		})
+	})
+}
+
+// filterTitle applies the filters to the inline text (the titles, the renames
+// and the dismissal messages); the sensitive data is redacted, the rewrite
+// rules are applied and the references are renumbered. The other filters are
+// only for the bodies, which rewrite the links.
+func (m *migrator) filterTitle(src string) string {
+	return m.renumberReferences(m.titleFilters.apply(src))
+}
+
+// renumberReferences rewrites the references to the source issues in the text
+// which is not filtered by the comment filters, like the titles.
+func (m *migrator) renumberReferences(src string) string {
+	if m.renumbering == nil {
+		return src
+	}
+	return m.renumberingFilter(src)
+}
@@ -0,0 +1,97 @@
+package migrator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func newRenumberingTestMigrator(renumbering Renumbering, sourceNumbers, targetNumbers []int) *migrator {
+	issues := func(numbers []int) github.Issues {
+		xs := make([]*github.Issue, len(numbers))
+		for i, n := range numbers {
+			xs[i] = &github.Issue{Number: n}
+		}
+		return github.IssuesFromSlice(xs)
+	}
+	m := &migrator{
+		source: repo.New(github.NewMockClient(
+			github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+				return issues(sourceNumbers)
+			}),
+		), "example/source"),
+		target: repo.New(github.NewMockClient(
+			github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+				return issues(targetNumbers)
+			}),
+		), "example/target"),
+		sourceRepo: &github.Repo{FullName: "example/source", HTMLURL: "http://localhost/example/source"},
+		targetRepo: &github.Repo{FullName: "example/target", HTMLURL: "http://localhost/example/target"},
+	}
+	MigratorRenumbering(renumbering)(m)
+	return m
+}
+
+func TestLoadIssueNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue-numbers.json")
+	m := newRenumberingTestMigrator(Renumbering{MapFile: path}, []int{1, 3, 4}, []int{1, 2})
+	assert.Nil(t, m.loadIssueNumbers())
+	assert.Equal(t, map[int]int{1: 3, 3: 4, 4: 5}, m.issueNumbers.Issues)
+
+	// the numbers in the map are kept in the later runs
+	m = newRenumberingTestMigrator(Renumbering{MapFile: path}, []int{1, 3, 4, 6}, []int{1, 2, 3, 4, 5})
+	assert.Nil(t, m.loadIssueNumbers())
+	assert.Equal(t, map[int]int{1: 3, 3: 4, 4: 5, 6: 6}, m.issueNumbers.Issues)
+	nm, err := LoadIssueNumberMap(path)
+	assert.Nil(t, err)
+	assert.Equal(t, &IssueNumberMap{
+		Source: "example/source",
+		Target: "example/target",
+		Issues: map[int]int{1: 3, 3: 4, 4: 5, 6: 6},
+	}, nm)
+	assert.Equal(t, 6, m.lookupIssueNumber(6))
+	assert.Equal(t, 7, m.lookupIssueNumber(7))
+
+	m = newRenumberingTestMigrator(Renumbering{MapFile: path}, []int{1}, []int{})
+	m.targetRepo.FullName = "example/other"
+	assert.EqualError(t, m.loadIssueNumbers(),
+		path+": the issue number map is for example/source => example/target")
+}
+
+func TestLoadIssueNumbersOffset(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue-numbers.json")
+	m := newRenumberingTestMigrator(Renumbering{Offset: 100, MapFile: path}, []int{1, 3}, []int{1, 2})
+	assert.Nil(t, m.loadIssueNumbers())
+	assert.Equal(t, map[int]int{1: 101, 3: 103}, m.issueNumbers.Issues)
+	assert.Equal(t, 102, m.lookupIssueNumber(2))
+
+	path = filepath.Join(t.TempDir(), "issue-numbers.json")
+	m = newRenumberingTestMigrator(Renumbering{Offset: 1, MapFile: path}, []int{1, 3}, []int{1, 2})
+	assert.EqualError(t, m.loadIssueNumbers(),
+		"cannot renumber http://localhost/example/source/issues/1 to #2: the target has issues up to #2 (increase the offset)")
+}
+
+func TestRenumberingFilter(t *testing.T) {
+	m := newRenumberingTestMigrator(Renumbering{MapFile: filepath.Join(t.TempDir(), "issue-numbers.json")},
+		[]int{1, 2}, []int{1, 2, 3})
+	assert.Nil(t, m.loadIssueNumbers())
+	m.renumberingFilter = m.newRenumberingFilter()
+	for _, tc := range []struct {
+		src, expected string
+	}{
+		{"#1", "#4"},
+		{"Fixes #1, #2 (#3).", "Fixes #4, #5 (#3)."},
+		{"See example/source#2 and other/source#2.", "See example/target#5 and other/source#2."},
+		{"http://localhost/example/source/issues/1#issuecomment-10",
+			"http://localhost/example/source/issues/4#issuecomment-10"},
+		{"http://localhost/example/source/pull/2/files", "http://localhost/example/source/pull/5/files"},
+		{"http://localhost/example/other/issues/1", "http://localhost/example/other/issues/1"},
+		{"&#1; issue#1 ##1", "&#1; issue#1 ##1"},
+	} {
+		assert.Equal(t, tc.expected, m.renumberReferences(tc.src))
+	}
+}
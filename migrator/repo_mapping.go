@@ -0,0 +1,109 @@
+package migrator
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RepoMappingEntry is an entry of the repository mapping. The issue numbers
+// are mapped with the issue number map file (saved by the renumbering or the
+// merging) when Issues is specified.
+type RepoMappingEntry struct {
+	Target       string
+	Issues       string
+	issueNumbers map[int]int
+}
+
+// RepoMapping maps the other repositories migrated in the same program by the
+// full names, to rewrite the links and the references to them.
+type RepoMapping map[string]*RepoMappingEntry
+
+// Load validates the repository mapping and loads the issue number maps.
+func (rm RepoMapping) Load() error {
+	for name, e := range rm {
+		if e == nil || e.Target == "" {
+			return fmt.Errorf("repo mapping %s: specify target", name)
+		}
+		if e.Issues == "" {
+			continue
+		}
+		nm, err := LoadIssueNumberMap(e.Issues)
+		if err != nil {
+			return fmt.Errorf("repo mapping %s: %w", name, err)
+		}
+		if !strings.EqualFold(nm.Source, name) {
+			return fmt.Errorf("repo mapping %s: %s: the issue number map is for %s", name, e.Issues, nm.Source)
+		}
+		e.issueNumbers = nm.Issues
+	}
+	return nil
+}
+
+// MigratorRepoMapping returns a migrator option to rewrite the links and the
+// references (owner/repo#N) to the other migrated repositories.
+func MigratorRepoMapping(mapping RepoMapping) MigratorOption {
+	return func(m *migrator) {
+		m.repoMapping = make(RepoMapping, len(mapping))
+		for k, v := range mapping {
+			m.repoMapping[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// lookupRepoMapping returns the mapping entry of the repository, except for
+// the migrating repository (which is handled by the other filters).
+func (m *migrator) lookupRepoMapping(name string) *RepoMappingEntry {
+	if strings.EqualFold(name, m.sourceRepo.FullName) {
+		return nil
+	}
+	return m.repoMapping[strings.ToLower(name)]
+}
+
+// issueNumber returns the issue number on the target repository.
+func (e *RepoMappingEntry) issueNumber(s string) string {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n, ok := e.issueNumbers[n]; ok {
+			return strconv.Itoa(n)
+		}
+	}
+	return s
+}
+
+// newRepoMappingFilter creates a filter to rewrite the links (on the source
+// host) and the references (owner/repo#N) to the mapped repositories. This
+// filter should be applied before the repository url of the source is
+// rewritten.
+func (m *migrator) newRepoMappingFilter() commentFilter {
+	sourceURL, _ := url.Parse(m.sourceRepo.HTMLURL)
+	targetURL, _ := url.Parse(m.targetRepo.HTMLURL)
+	sourceBase := sourceURL.Scheme + "://" + sourceURL.Host
+	targetBase := targetURL.Scheme + "://" + targetURL.Host
+	urlPattern := regexp.MustCompile(
+		`(?i)` + regexp.QuoteMeta(sourceBase) + `/([\w.-]+/[\w.-]+)(?:/(issues|pull)/(\d+))?`,
+	)
+	refPattern := regexp.MustCompile(`(^|[^\w./-])([\w.-]+/[\w.-]+)#(\d+)\b`)
+	return commentFilter(func(src string) string {
+		src = urlPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := urlPattern.FindStringSubmatch(s)
+			e := m.lookupRepoMapping(xs[1])
+			if e == nil {
+				return s
+			}
+			if xs[2] != "" {
+				return targetBase + "/" + e.Target + "/" + xs[2] + "/" + e.issueNumber(xs[3])
+			}
+			return targetBase + "/" + e.Target
+		})
+		return refPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := refPattern.FindStringSubmatch(s)
+			e := m.lookupRepoMapping(xs[2])
+			if e == nil {
+				return s
+			}
+			return xs[1] + e.Target + "#" + e.issueNumber(xs[3])
+		})
+	})
+}
@@ -0,0 +1,65 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestRepoMappingLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issue-numbers.json")
+	assert.Nil(t, os.WriteFile(path, []byte(`{
+  "source": "team/other",
+  "target": "new-team/other",
+  "issues": { "5": 105 }
+}
+`), 0644))
+	rm := RepoMapping{"Team/Other": {Target: "new-team/other", Issues: path}}
+	assert.Nil(t, rm.Load())
+	assert.Equal(t, map[int]int{5: 105}, rm["Team/Other"].issueNumbers)
+
+	assert.EqualError(t, RepoMapping{"team/other": {}}.Load(),
+		"repo mapping team/other: specify target")
+	assert.EqualError(t, RepoMapping{"team/lib": {Target: "new-team/lib", Issues: path}}.Load(),
+		"repo mapping team/lib: "+path+": the issue number map is for team/other")
+}
+
+func TestRepoMappingFilter(t *testing.T) {
+	m := &migrator{
+		sourceRepo: &github.Repo{FullName: "team/source", HTMLURL: "https://ghe.example.com/team/source"},
+		targetRepo: &github.Repo{FullName: "new-team/source", HTMLURL: "https://github.com/new-team/source"},
+	}
+	MigratorRepoMapping(RepoMapping{
+		"team/other":  {Target: "new-team/other", issueNumbers: map[int]int{5: 105}},
+		"Team/Lib":    {Target: "new-team/lib"},
+		"team/source": {Target: "new-team/wrong"},
+	})(m)
+	filter := m.newRepoMappingFilter()
+	for _, tc := range []struct {
+		src, expected string
+	}{
+		{
+			"See https://ghe.example.com/team/other/issues/5 and https://ghe.example.com/team/other/pull/6.",
+			"See https://github.com/new-team/other/issues/105 and https://github.com/new-team/other/pull/6.",
+		},
+		{
+			"https://ghe.example.com/team/lib/blob/master/README.md",
+			"https://github.com/new-team/lib/blob/master/README.md",
+		},
+		{"team/other#5, (team/lib#1) TEAM/LIB#2", "new-team/other#105, (new-team/lib#1) new-team/lib#2"},
+		{
+			"https://ghe.example.com/team/unknown/issues/5 team/unknown#5 team/other-x#5",
+			"https://ghe.example.com/team/unknown/issues/5 team/unknown#5 team/other-x#5",
+		},
+		{
+			"https://ghe.example.com/team/source/issues/1 team/source#1",
+			"https://ghe.example.com/team/source/issues/1 team/source#1",
+		},
+	} {
+		assert.Equal(t, tc.expected, filter(tc.src))
+	}
+}
@@ -0,0 +1,32 @@
+package migrator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// loadReport loads the report file written by the previous runs, so that the
+// entries of the skipped issues are kept in the report.
+func loadReport(path string, v interface{}) error {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := json.Unmarshal(bs, v); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// saveReport writes the report file.
+func saveReport(path string, v interface{}) error {
+	bs, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0644)
+}
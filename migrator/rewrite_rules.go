@@ -0,0 +1,85 @@
+package migrator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RewriteRule is a rule to rewrite the text of the issues and comments.
+// Either Literal (a plain string) or Regexp (a regular expression, with the
+// submatches available as $1 in Replace) should be specified.
+type RewriteRule struct {
+	Literal string
+	Regexp  string
+	Replace string
+	re      *regexp.Regexp
+}
+
+func (r *RewriteRule) String() string {
+	if r.Regexp != "" {
+		return fmt.Sprintf("/%s/ => %q", r.Regexp, r.Replace)
+	}
+	return fmt.Sprintf("%q => %q", r.Literal, r.Replace)
+}
+
+// RewriteRules is the list of rules applied in order.
+type RewriteRules []*RewriteRule
+
+// Compile validates the rules and compiles the regular expressions.
+func (rs RewriteRules) Compile() error {
+	for i, r := range rs {
+		if (r.Literal == "") == (r.Regexp == "") {
+			return fmt.Errorf("rewrite rule %d: specify either literal or regexp", i+1)
+		}
+		if r.Regexp != "" {
+			re, err := regexp.Compile(r.Regexp)
+			if err != nil {
+				return fmt.Errorf("rewrite rule %d: %w", i+1, err)
+			}
+			r.re = re
+		}
+	}
+	return nil
+}
+
+// Apply rewrites the text with the rules. The rules should be compiled.
+func (rs RewriteRules) Apply(src string) string {
+	for _, r := range rs {
+		src = r.Apply(src)
+	}
+	return src
+}
+
+// Apply rewrites the text with the rule.
+func (r *RewriteRule) Apply(src string) string {
+	if r.re != nil {
+		return r.re.ReplaceAllString(src, r.Replace)
+	}
+	return strings.ReplaceAll(src, r.Literal, r.Replace)
+}
+
+// Count returns the number of the matches of the rule in the text.
+func (r *RewriteRule) Count(src string) int {
+	if r.re != nil {
+		return len(r.re.FindAllStringIndex(src, -1))
+	}
+	return strings.Count(src, r.Literal)
+}
+
+// MigratorRewriteRules returns a migrator option to rewrite the text of the
+// issues, comments, commit messages and project notes with the rules. The
+// rules are applied to the source text, before the repository urls and the
+// users are rewritten.
+func MigratorRewriteRules(rules RewriteRules) MigratorOption {
+	return func(m *migrator) {
+		m.rewriteRules = rules
+	}
+}
+
+func (m *migrator) newRewriteRulesFilter() (commentFilter, error) {
+	if err := m.rewriteRules.Compile(); err != nil {
+		return nil, err
+	}
+	return commentFilter(m.rewriteRules.Apply), nil
+}
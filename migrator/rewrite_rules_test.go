@@ -0,0 +1,46 @@
+package migrator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestRewriteRules(t *testing.T) {
+	rules := RewriteRules{
+		{Literal: "wiki.internal", Replace: "wiki.example.com"},
+		{Regexp: `\bPRJ-(\d+)\b`, Replace: "EXAMPLE-$1"},
+	}
+	require.NoError(t, rules.Compile())
+	src := "See PRJ-1 and PRJ-20 on https://wiki.internal/PRJ-1."
+	assert.Equal(t, 1, rules[0].Count(src))
+	assert.Equal(t, 3, rules[1].Count(src))
+	assert.Equal(t, "See EXAMPLE-1 and EXAMPLE-20 on https://wiki.example.com/EXAMPLE-1.", rules.Apply(src))
+	assert.Equal(t, `"wiki.internal" => "wiki.example.com"`, rules[0].String())
+	assert.Equal(t, `/\bPRJ-(\d+)\b/ => "EXAMPLE-$1"`, rules[1].String())
+}
+
+func TestRewriteRulesCompileError(t *testing.T) {
+	assert.EqualError(t, RewriteRules{{Replace: "x"}}.Compile(),
+		"rewrite rule 1: specify either literal or regexp")
+	assert.EqualError(t, RewriteRules{{Literal: "x"}, {Literal: "x", Regexp: "x"}}.Compile(),
+		"rewrite rule 2: specify either literal or regexp")
+	assert.Error(t, RewriteRules{{Regexp: "("}}.Compile())
+}
+
+func TestRewriteRulesLogins(t *testing.T) {
+	m := &migrator{userMapping: map[string]string{"sample-user-1": "new-user-1"}}
+	MigratorRewriteRules(RewriteRules{{Literal: "cafe", Replace: "coffee"}})(m)
+	filter, err := m.newRewriteRulesFilter()
+	require.NoError(t, err)
+	m.commentFilters = newCommentFilters(filter)
+	b := &builder{migrator: m}
+	assert.Equal(t, "coffee1234", b.commentFilters.apply("cafe1234"))
+	// the logins are mapped only with the user mapping
+	assert.Equal(t, "cafe1234", b.getUserLogin(&github.User{Login: "cafe1234"}))
+	assert.Equal(t, "new-user-1", b.getUserLogin(&github.User{Login: "sample-user-1"}))
+	assert.Equal(t, []string{"cafe1234", "new-user-1"}, b.buildLogins([]*github.User{{Login: "cafe1234"}, {Login: "sample-user-1"}}))
+}
@@ -0,0 +1,136 @@
+package migrator
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// sourceUsers is the users appearing in the source repository.
+type sourceUsers struct {
+	issueNumbers map[string][]int
+	commitEmails map[string][]string
+}
+
+// scanSourceUsers scans the users in the issues, comments, events, reviews
+// and commits of the source repository.
+func scanSourceUsers(source *repo.Repo) (*sourceUsers, error) {
+	us := &sourceUsers{
+		issueNumbers: make(map[string][]int),
+		commitEmails: make(map[string][]string),
+	}
+	issues := source.ListIssues()
+	for {
+		issue, err := issues.Next()
+		if err != nil {
+			if err != io.EOF {
+				return nil, err
+			}
+			return us, nil
+		}
+		fmt.Printf("[|>] scanning the users in %s\n", issue.HTMLURL)
+		add := func(users ...*github.User) {
+			for _, u := range users {
+				us.add(u, issue.Number)
+			}
+		}
+		add(issue.User, issue.Assignee)
+		add(issue.Assignees...)
+		comments, err := github.CommentsToSlice(source.ListComments(issue.Number))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range comments {
+			add(c.User)
+		}
+		events, err := github.EventsToSlice(source.ListEvents(issue.Number))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			add(e.Actor, e.Assignee, e.Assigner, e.Reviewer)
+			add(e.Assignees...)
+			add(e.Reviewers...)
+		}
+		if issue.PullRequest == nil {
+			continue
+		}
+		reviews, err := github.ReviewsToSlice(source.ListReviews(issue.Number))
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range reviews {
+			add(r.User)
+		}
+		reviewComments, err := github.ReviewCommentsToSlice(source.ListReviewComments(issue.Number))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range reviewComments {
+			add(c.User)
+		}
+		commits, err := github.CommitsToSlice(source.ListPullReqCommits(issue.Number))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range commits {
+			add(c.Author, c.Committer)
+			if c.Author != nil && c.Commit.Author != nil {
+				us.addEmail(c.Author.Login, c.Commit.Author.Email)
+			}
+		}
+	}
+}
+
+func (us *sourceUsers) add(user *github.User, number int) {
+	if user == nil || user.Login == "" || user.Login == "ghost" {
+		return
+	}
+	numbers := us.issueNumbers[user.Login]
+	if len(numbers) > 0 && numbers[len(numbers)-1] == number {
+		return
+	}
+	us.issueNumbers[user.Login] = append(numbers, number)
+}
+
+// addEmail adds the email of the commit author, which is usually available
+// even if the email of the profile is private.
+func (us *sourceUsers) addEmail(name, email string) {
+	if email == "" || strings.HasSuffix(email, "@users.noreply.github.com") ||
+		containsString(us.commitEmails[name], email) {
+		return
+	}
+	us.commitEmails[name] = append(us.commitEmails[name], email)
+}
+
+func (us *sourceUsers) names() []string {
+	names := make([]string, 0, len(us.issueNumbers))
+	for name := range us.issueNumbers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatIssueNumbers formats the issues where the user appears, for finding
+// the context of the user.
+func (us *sourceUsers) formatIssueNumbers(name string) string {
+	return formatIssueNumbers(us.issueNumbers[name])
+}
+
+func formatIssueNumbers(numbers []int) string {
+	const limit = 5
+	xs := make([]string, 0, limit+1)
+	for i, number := range numbers {
+		if i == limit {
+			xs = append(xs, fmt.Sprintf("and %d more", len(numbers)-limit))
+			break
+		}
+		xs = append(xs, fmt.Sprintf("#%d", number))
+	}
+	return strings.Join(xs, ", ")
+}
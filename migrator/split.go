@@ -0,0 +1,352 @@
+package migrator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// RoutingRule is a rule to route the source issues to the target repository.
+// The issue matches the rule when it matches all the specified conditions; any
+// of the labels, any of the path prefixes of the files changed by the pull
+// request, the milestone title, and the title regular expression. The rule
+// without the conditions matches all the issues.
+type RoutingRule struct {
+	Target    string
+	Labels    []string
+	Paths     []string
+	Milestone string
+	Title     string
+	title     *regexp.Regexp
+}
+
+func (r *RoutingRule) String() string {
+	var xs []string
+	if len(r.Labels) > 0 {
+		xs = append(xs, "labels: "+strings.Join(r.Labels, ", "))
+	}
+	if len(r.Paths) > 0 {
+		xs = append(xs, "paths: "+strings.Join(r.Paths, ", "))
+	}
+	if r.Milestone != "" {
+		xs = append(xs, "milestone: "+r.Milestone)
+	}
+	if r.Title != "" {
+		xs = append(xs, "title: /"+r.Title+"/")
+	}
+	if len(xs) == 0 {
+		return "default"
+	}
+	return strings.Join(xs, "; ")
+}
+
+// RoutingRules is the list of rules; the first matching rule is used.
+type RoutingRules []*RoutingRule
+
+// Compile validates the rules and compiles the regular expressions.
+func (rs RoutingRules) Compile() error {
+	if len(rs) == 0 {
+		return errors.New("no routing rules")
+	}
+	for i, r := range rs {
+		if r.Target == "" {
+			return fmt.Errorf("routing rule %d: specify target", i+1)
+		}
+		if r.Title != "" {
+			re, err := regexp.Compile(r.Title)
+			if err != nil {
+				return fmt.Errorf("routing rule %d: %w", i+1, err)
+			}
+			r.title = re
+		}
+	}
+	return nil
+}
+
+// Targets returns the target repositories of the rules.
+func (rs RoutingRules) Targets() []string {
+	var xs []string
+	for _, r := range rs {
+		if !containsString(xs, r.Target) {
+			xs = append(xs, r.Target)
+		}
+	}
+	return xs
+}
+
+// SplitIndex records the target repositories and the numbers of the source
+// issues.
+type SplitIndex struct {
+	Source string                      `json:"source"`
+	Issues map[int]*SplitIndexLocation `json:"issues"`
+}
+
+// SplitIndexLocation is the location of the issue on the target.
+type SplitIndexLocation struct {
+	Target string `json:"target"`
+	Number int    `json:"number"`
+	Rule   string `json:"rule"`
+}
+
+// LoadSplitIndex loads the split index from the JSON file.
+func LoadSplitIndex(path string) (*SplitIndex, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var si SplitIndex
+	if err := json.Unmarshal(bs, &si); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if si.Issues == nil {
+		si.Issues = make(map[int]*SplitIndexLocation)
+	}
+	return &si, nil
+}
+
+// Save saves the split index to the JSON file.
+func (si *SplitIndex) Save(path string) error {
+	bs, err := json.MarshalIndent(si, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0o644)
+}
+
+// routedIssue is the issue migrated to the other target repository.
+type routedIssue struct {
+	repo   *github.Repo
+	number int
+}
+
+// NewSplitter creates a new Migrator, which splits the issues of the source
+// repository across the target repositories with the routing rules. Each target
+// gets its own numbering (the next free numbers), and the references to the
+// issues migrated to the other targets are rewritten to owner/repo#N. The
+// routes and the numbers are recorded in the index file, which is used by the
+// later runs. The labels, milestones, projects and hooks are migrated to all
+// the targets. The targets should be in the order of the targets of the rules.
+func NewSplitter(
+	source *repo.Repo, targets []*repo.Repo, rules RoutingRules, indexPath string,
+	userMapping map[string]string, opts ...MigratorOption,
+) Migrator {
+	ms := make([]*migrator, len(targets))
+	for i, target := range targets {
+		m := New(source, target, userMapping, opts...).(*migrator)
+		m.renumbering = &Renumbering{}
+		if i > 0 {
+			// share the attachments manifest and the reports
+			m.attachments, m.redaction, m.hostLinks = ms[0].attachments, ms[0].redaction, ms[0].hostLinks
+		}
+		ms[i] = m
+	}
+	return &splitter{migrators: ms, rules: rules, indexPath: indexPath}
+}
+
+type splitter struct {
+	migrators []*migrator
+	rules     RoutingRules
+	indexPath string
+	issues    [][]*github.Issue
+}
+
+// Migrate splits the repository.
+func (sp *splitter) Migrate() error {
+	for _, m := range sp.migrators {
+		if err := m.prepare(); err != nil {
+			return err
+		}
+	}
+	if err := sp.routeIssues(); err != nil {
+		return err
+	}
+	for _, m := range sp.migrators {
+		if err := m.migrateRepo(); err != nil {
+			return err
+		}
+		if err := m.migrateBeforeIssues(); err != nil {
+			return err
+		}
+	}
+	for i, m := range sp.migrators {
+		targetIssuesBuffer := newIssuesBuffer(m.target.ListIssues())
+		for _, issue := range sp.issues[i] {
+			if err := m.migrateIssueAndWait(issue, targetIssuesBuffer, false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range sp.migrators {
+		if err := m.migrateAfterIssues(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// routeIssues loads the split index, and routes the source issues which are
+// not in the index yet to the targets with the next free numbers.
+func (sp *splitter) routeIssues() error {
+	m := sp.migrators[0]
+	si, err := LoadSplitIndex(sp.indexPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		si = &SplitIndex{Source: m.sourceRepo.FullName, Issues: make(map[int]*SplitIndexLocation)}
+	} else if si.Source != m.sourceRepo.FullName {
+		return fmt.Errorf("%s: the split index is for %s", sp.indexPath, si.Source)
+	}
+	ruleTargets := make(map[string]int, len(sp.migrators))
+	for i, target := range sp.rules.Targets() {
+		ruleTargets[target] = i
+	}
+	targetIndex := make(map[string]int, len(sp.migrators))
+	nms := make([]*IssueNumberMap, len(sp.migrators))
+	for i, m := range sp.migrators {
+		targetIndex[m.targetRepo.FullName] = i
+		nms[i] = &IssueNumberMap{
+			Source: m.sourceRepo.FullName,
+			Target: m.targetRepo.FullName,
+			Issues: make(map[int]int),
+		}
+	}
+	for n, l := range si.Issues {
+		i, ok := targetIndex[l.Target]
+		if !ok {
+			return fmt.Errorf("%s: unknown target of %s/issues/%d: %s", sp.indexPath, m.sourceRepo.HTMLURL, n, l.Target)
+		}
+		nms[i].Issues[n] = l.Number
+	}
+	nexts := make([]int, len(sp.migrators))
+	for i, m := range sp.migrators {
+		if err := m.loadLastTargetIssueNumber(); err != nil {
+			return err
+		}
+		nexts[i] = nextIssueNumber(m.lastTargetIssueNumber, nms[i])
+	}
+	issues, err := github.IssuesToSlice(m.source.ListIssues())
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Number < issues[j].Number
+	})
+	sp.issues = make([][]*github.Issue, len(sp.migrators))
+	counts := make([]int, len(sp.migrators))
+	for _, issue := range issues {
+		if l, ok := si.Issues[issue.Number]; ok {
+			i := targetIndex[l.Target]
+			sp.issues[i] = append(sp.issues[i], issue)
+			continue
+		}
+		r, err := sp.route(issue)
+		if err != nil {
+			return err
+		}
+		i := ruleTargets[r.Target]
+		number := nexts[i]
+		nexts[i]++
+		fmt.Printf("[<>] routing an issue: %s => %s#%d (%s)\n",
+			issue.HTMLURL, sp.migrators[i].targetRepo.FullName, number, r)
+		si.Issues[issue.Number] = &SplitIndexLocation{
+			Target: sp.migrators[i].targetRepo.FullName, Number: number, Rule: r.String(),
+		}
+		nms[i].Issues[issue.Number] = number
+		sp.issues[i] = append(sp.issues[i], issue)
+		counts[i]++
+	}
+	if err := si.Save(sp.indexPath); err != nil {
+		return err
+	}
+	for i, m := range sp.migrators {
+		fmt.Printf("[<>] routed %s to %s (%d new)\n",
+			plural(len(nms[i].Issues), "issue"), m.targetRepo.FullName, counts[i])
+		m.issueNumbers = nms[i]
+		m.routedIssues = make(map[int]*routedIssue)
+		for j, n := range sp.migrators {
+			if i == j {
+				continue
+			}
+			for k, v := range nms[j].Issues {
+				m.routedIssues[k] = &routedIssue{repo: n.targetRepo, number: v}
+			}
+		}
+	}
+	fmt.Printf("[<>] saved the split index to %s\n", sp.indexPath)
+	return nil
+}
+
+// route finds the routing rule of the issue.
+func (sp *splitter) route(issue *github.Issue) (*RoutingRule, error) {
+	var paths []string
+	for _, r := range sp.rules {
+		if len(r.Labels) > 0 {
+			var found bool
+			for _, l := range issue.Labels {
+				if containsLabel(r.Labels, l.Name) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if r.Milestone != "" && (issue.Milestone == nil || issue.Milestone.Title != r.Milestone) {
+			continue
+		}
+		if r.title != nil && !r.title.MatchString(issue.Title) {
+			continue
+		}
+		if len(r.Paths) > 0 {
+			if issue.PullRequest == nil {
+				continue
+			}
+			if paths == nil {
+				var err error
+				if paths, err = sp.listPullReqPaths(issue); err != nil {
+					return nil, err
+				}
+			}
+			if !matchPathPrefixes(paths, r.Paths) {
+				continue
+			}
+		}
+		return r, nil
+	}
+	return nil, fmt.Errorf("no routing rule matched: %s (add a rule without conditions as the default)", issue.HTMLURL)
+}
+
+// listPullReqPaths lists the paths of the files changed by the pull request.
+func (sp *splitter) listPullReqPaths(issue *github.Issue) ([]string, error) {
+	m := sp.migrators[0]
+	pullReq, err := m.source.GetPullReq(issue.Number)
+	if err != nil {
+		return nil, err
+	}
+	diff, err := m.source.NewPath(pullReq.Base.Repo.FullName).
+		GetCompare(pullReq.Base.SHA, pullReq.Head.SHA)
+	if err != nil {
+		return nil, err
+	}
+	return diffPaths(diff), nil
+}
+
+func matchPathPrefixes(paths, prefixes []string) bool {
+	for _, path := range paths {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
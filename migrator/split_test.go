@@ -0,0 +1,202 @@
+package migrator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+const splitTestYAML = `
+source:
+  repo:
+    name: mono
+    full_name: example/mono
+    html_url: http://localhost/example/mono
+  labels:
+    - name: api
+      color: d73a4a
+  issues:
+    - number: 1
+      title: API issue
+      body: "See #2 and #3."
+      html_url: http://localhost/example/mono/issues/1
+      state: open
+      labels:
+        - name: api
+      created_at: 2019-11-18T12:00:00Z
+    - number: 2
+      title: "[web] Web issue"
+      body: "Caused by http://localhost/example/mono/issues/1."
+      html_url: http://localhost/example/mono/issues/2
+      state: open
+      created_at: 2019-11-18T13:00:00Z
+    - number: 3
+      title: Other issue
+      html_url: http://localhost/example/mono/issues/3
+      state: open
+      created_at: 2019-11-18T14:00:00Z
+
+targets:
+  - repo:
+      name: api
+      full_name: example/api
+      html_url: http://localhost/example/api
+    labels:
+      - name: api
+        color: d73a4a
+    issues:
+      - number: 1
+        title: Existing issue 1
+        state: open
+      - number: 2
+        title: Existing issue 2
+        state: open
+    imports:
+      - issue:
+          title: API issue
+          body: |-
+            <table>
+            <tr>
+              <td width="60">
+                <img src="https://github.com/ghost.png" width="35">
+              </td>
+              <td>
+                @ghost created the original issue<br>
+                imported from <a href="http://localhost/example/mono/issues/1">example/mono#1</a>
+              </td>
+            </tr>
+            </table>
+
+
+            See example/web#1 and #4.
+          created_at: 2019-11-18T12:00:00Z
+          closed: false
+          labels: [api]
+        comments: []
+      - issue:
+          title: Other issue
+          body: |
+            <table>
+            <tr>
+              <td width="60">
+                <img src="https://github.com/ghost.png" width="35">
+              </td>
+              <td>
+                @ghost created the original issue<br>
+                imported from <a href="http://localhost/example/mono/issues/3">example/mono#3</a>
+              </td>
+            </tr>
+            </table>
+          created_at: 2019-11-18T14:00:00Z
+          closed: false
+          labels: []
+        comments: []
+  - repo:
+      name: web
+      full_name: example/web
+      html_url: http://localhost/example/web
+    create_labels:
+      - name: api
+        color: d73a4a
+    imports:
+      - issue:
+          title: "[web] Web issue"
+          body: |-
+            <table>
+            <tr>
+              <td width="60">
+                <img src="https://github.com/ghost.png" width="35">
+              </td>
+              <td>
+                @ghost created the original issue<br>
+                imported from <a href="http://localhost/example/mono/issues/2">example/mono#2</a>
+              </td>
+            </tr>
+            </table>
+
+
+            Caused by http://localhost/example/api/issues/3.
+          created_at: 2019-11-18T13:00:00Z
+          closed: false
+          labels: []
+        comments: []
+`
+
+func TestSplitterMigrate(t *testing.T) {
+	var tc struct {
+		Source  *testRepo   `json:"source"`
+		Targets []*testRepo `json:"targets"`
+	}
+	require.NoError(t, decodeYAML(strings.NewReader(splitTestYAML), &tc))
+	source := tc.Source.build(t, false)
+	targets := make([]*repo.Repo, len(tc.Targets))
+	for i, r := range tc.Targets {
+		targets[i] = r.build(t, true)
+	}
+	rules := RoutingRules{
+		{Target: "example/api", Labels: []string{"API"}},
+		{Target: "example/web", Title: `^\[web\]`},
+		{Target: "example/api"},
+	}
+	require.NoError(t, rules.Compile())
+	assert.Equal(t, []string{"example/api", "example/web"}, rules.Targets())
+	path := filepath.Join(t.TempDir(), "split-index.json")
+	assert.Nil(t, NewSplitter(source, targets, rules, path, nil).Migrate())
+
+	si, err := LoadSplitIndex(path)
+	assert.Nil(t, err)
+	assert.Equal(t, &SplitIndex{
+		Source: "example/mono",
+		Issues: map[int]*SplitIndexLocation{
+			1: {Target: "example/api", Number: 3, Rule: "labels: API"},
+			2: {Target: "example/web", Number: 1, Rule: `title: /^\[web\]/`},
+			3: {Target: "example/api", Number: 4, Rule: "default"},
+		},
+	}, si)
+}
+
+func TestSplitterRoute(t *testing.T) {
+	source := repo.New(github.NewMockClient(
+		github.MockGetPullReq(func(_ string, pullNumber int) (*github.PullReq, error) {
+			return &github.PullReq{
+				Base: &github.PullReqRef{SHA: "base", Repo: &github.Repo{FullName: "example/mono"}},
+				Head: &github.PullReqRef{SHA: "head"},
+			}, nil
+		}),
+		github.MockGetCompare(func(_ string, base, head string) (string, error) {
+			return `diff --git a/web/index.html b/web/index.html
+index 1234567..89abcde 100644
+`, nil
+		}),
+	), "example/mono")
+	sp := &splitter{
+		migrators: []*migrator{{source: source}},
+		rules: RoutingRules{
+			{Target: "example/web", Paths: []string{"web/"}},
+			{Target: "example/api", Milestone: "v1.0"},
+		},
+	}
+	require.NoError(t, sp.rules.Compile())
+	r, err := sp.route(&github.Issue{Number: 1, PullRequest: &github.IssuePullRequest{}})
+	assert.Nil(t, err)
+	assert.Equal(t, "example/web", r.Target)
+	r, err = sp.route(&github.Issue{Number: 2, Milestone: &github.Milestone{Title: "v1.0"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "example/api", r.Target)
+	_, err = sp.route(&github.Issue{Number: 3, HTMLURL: "http://localhost/example/mono/issues/3"})
+	assert.EqualError(t, err, "no routing rule matched: http://localhost/example/mono/issues/3 (add a rule without conditions as the default)")
+}
+
+func TestRoutingRulesCompile(t *testing.T) {
+	assert.EqualError(t, RoutingRules{}.Compile(), "no routing rules")
+	assert.EqualError(t, RoutingRules{{Labels: []string{"api"}}}.Compile(),
+		"routing rule 1: specify target")
+	assert.EqualError(t, RoutingRules{{Target: "example/api", Title: "("}}.Compile(),
+		"routing rule 1: error parsing regexp: missing closing ): `(`")
+}
@@ -0,0 +1,113 @@
+package migrator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// MigratorTeamMapping returns a migrator option to map the teams (org/team)
+// of the source to the teams of the target, in the requested reviewers of the
+// events and the team mentions in the bodies. When derive is true, the teams
+// not in the mapping are mapped to the teams with the same slugs in the
+// organization of the target repository.
+func MigratorTeamMapping(mapping map[string]string, derive bool) MigratorOption {
+	return func(m *migrator) {
+		m.teamMapping = make(map[string]string, len(mapping))
+		for k, v := range mapping {
+			m.teamMapping[strings.ToLower(k)] = v
+		}
+		m.deriveTeams = derive
+	}
+}
+
+// loadTeams validates the mapped teams on the target, and lists the teams of
+// the target organization to derive the mapping.
+func (m *migrator) loadTeams() error {
+	if len(m.teamMapping) == 0 && !m.deriveTeams {
+		return nil
+	}
+	m.targetTeams = make(map[string]*github.Team)
+	if m.deriveTeams {
+		teams, err := github.TeamsToSlice(m.target.ListTeams())
+		if err != nil {
+			return err
+		}
+		for _, t := range teams {
+			m.targetTeams[strings.ToLower(m.targetOrg()+"/"+t.Slug)] = t
+		}
+	}
+	sources := make([]string, 0, len(m.teamMapping))
+	for k := range m.teamMapping {
+		sources = append(sources, k)
+	}
+	sort.Strings(sources)
+	for _, source := range sources {
+		target := m.teamMapping[source]
+		if _, ok := m.targetTeams[strings.ToLower(target)]; !ok {
+			i := strings.IndexByte(target, '/')
+			if i < 0 {
+				return fmt.Errorf("invalid team: %s (specify org/team)", target)
+			}
+			t, err := m.target.GetTeam(target[:i], target[i+1:])
+			if err != nil {
+				return fmt.Errorf("team not found on the target: %s: %w", target, err)
+			}
+			m.targetTeams[strings.ToLower(target)] = t
+		}
+		fmt.Printf("[<>] mapping a team: %s => %s\n", source, target)
+	}
+	return nil
+}
+
+func (m *migrator) targetOrg() string {
+	return strings.Split(m.targetRepo.FullName, "/")[0]
+}
+
+// lookupTeam returns the name (org/team) and the team on the target mapped
+// from the team (org/team) of the source.
+func (m *migrator) lookupTeam(name string) (string, *github.Team) {
+	if target, ok := m.teamMapping[strings.ToLower(name)]; ok {
+		return target, m.targetTeams[strings.ToLower(target)]
+	}
+	if m.deriveTeams {
+		if i := strings.IndexByte(name, '/'); i >= 0 {
+			if t, ok := m.targetTeams[strings.ToLower(m.targetOrg()+"/"+name[i+1:])]; ok {
+				return m.targetOrg() + "/" + t.Slug, t
+			}
+		}
+	}
+	return "", nil
+}
+
+// newTeamMappingFilter creates a filter to replace the team mentions.
+func (m *migrator) newTeamMappingFilter() commentFilter {
+	return commentFilter(func(src string) string {
+		return mentionPattern.ReplaceAllStringFunc(src, func(s string) string {
+			xs := mentionPattern.FindStringSubmatch(s)
+			if !strings.Contains(xs[2], "/") {
+				return s
+			}
+			target, _ := m.lookupTeam(xs[2])
+			if target == "" {
+				return s
+			}
+			return xs[1] + "@" + target
+		})
+	})
+}
+
+// buildTeamName renders the requested team of the event, which is mapped to
+// the team of the target if configured.
+func (b *builder) buildTeamName(team *github.EventTeam) string {
+	slug := team.Slug
+	if slug == "" {
+		slug = strings.ReplaceAll(strings.ToLower(team.Name), " ", "-")
+	}
+	if _, t := b.lookupTeam(strings.Split(b.sourceRepo.FullName, "/")[0] + "/" + slug); t != nil {
+		return t.Name
+	}
+	return b.commentFilters.apply(team.Name)
+}
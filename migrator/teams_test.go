@@ -0,0 +1,27 @@
+package migrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestLoadTeamsNotFound(t *testing.T) {
+	m := &migrator{
+		target: repo.New(github.NewMockClient(
+			github.MockGetTeam(func(org, slug string) (*github.Team, error) {
+				return nil, errors.New("Not Found")
+			}),
+		), "new-example/target"),
+		targetRepo: &github.Repo{FullName: "new-example/target"},
+	}
+	MigratorTeamMapping(map[string]string{"example/reviewers": "new-example/reviewers"}, false)(m)
+	assert.EqualError(t, m.loadTeams(), "team not found on the target: new-example/reviewers: Not Found")
+
+	MigratorTeamMapping(map[string]string{"example/reviewers": "reviewers"}, false)(m)
+	assert.EqualError(t, m.loadTeams(), "invalid team: reviewers (specify org/team)")
+}
@@ -0,0 +1,206 @@
+package migrator
+
+import (
+	"embed"
+	"fmt"
+	"html"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// MigratorTemplates returns a migrator option to override the templates of
+// the issues, comments and events with the template files (*.tmpl) in the
+// directory. The templates defined in the files replace the default ones
+// with the same names, and the others are kept.
+func MigratorTemplates(dir string) MigratorOption {
+	return func(m *migrator) {
+		m.templatesDir = dir
+	}
+}
+
+// loadTemplates loads the default templates and the template files in the
+// directory. The mention function renders the mentions in the headers.
+func loadTemplates(dir string, mention func(string) string) (*template.Template, error) {
+	t := template.New("")
+	render := func(name string, data interface{}) (string, error) {
+		return executeTemplate(t, name, data)
+	}
+	t.Funcs(template.FuncMap{
+		"render": render,
+		"rows": func(name string, items interface{}) ([][]string, error) {
+			v := reflect.ValueOf(items)
+			if v.Kind() != reflect.Slice {
+				return nil, fmt.Errorf("rows: expected a slice but got %T", items)
+			}
+			xss := make([][]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				x, err := render(name, v.Index(i).Interface())
+				if err != nil {
+					return nil, err
+				}
+				xss = append(xss, []string{x})
+			}
+			return xss, nil
+		},
+		"row":        buildTableRow,
+		"table":      buildTable,
+		"details":    buildDetails,
+		"escape":     html.EscapeString,
+		"plural":     plural,
+		"pluralUnit": pluralUnit,
+		"formatTime": formatTime,
+		"mention":    mention,
+		"mentions": func(users []string) string {
+			xs := make([]string, len(users))
+			for i, u := range users {
+				xs[i] = mention(u)
+			}
+			return strings.Join(xs, " ")
+		},
+	})
+	if _, err := t.ParseFS(templateFS, "templates/*.tmpl"); err != nil {
+		return nil, err
+	}
+	if dir != "" {
+		if _, err := t.ParseGlob(filepath.Join(dir, "*.tmpl")); err != nil {
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+	}
+	return t, nil
+}
+
+func executeTemplate(t *template.Template, name string, data interface{}) (string, error) {
+	s := new(strings.Builder)
+	if err := t.ExecuteTemplate(s, name, data); err != nil {
+		return "", err
+	}
+	return s.String(), nil
+}
+
+func (m *migrator) loadTemplates() (err error) {
+	m.templates, err = loadTemplates(m.templatesDir, m.mentionHTML)
+	return
+}
+
+func (m *migrator) render(name string, data interface{}) (string, error) {
+	return executeTemplate(m.templates, name, data)
+}
+
+func formatTime(layout, s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return ""
+	}
+	return t.Format(layout)
+}
+
+// The following types are passed to the templates. The logins are the ones
+// in the target host (after the user mapping is applied), and the bodies are
+// already rewritten for the target repository.
+
+type userData struct {
+	Login     string
+	AvatarURL string
+}
+
+type issueData struct {
+	User        *userData
+	Type        string
+	Number      int
+	Title       string
+	CreatedAt   string
+	Timestamp   string
+	OriginalURL string
+	SourceRepo  string
+	PullRequest *pullRequestData
+	Commits     []*commitData
+	MoreCommits int
+	Body        string
+	Reactions   reactionsData
+}
+
+type pullRequestData struct {
+	BaseRef, HeadRef           string
+	BaseSHA, HeadSHA           string
+	BaseShortSHA, HeadShortSHA string
+	CompareURL                 string
+	ChangedFiles               int
+	Additions, Deletions       int
+	CommitCount                int
+	Diff                       string
+	Dropped                    bool
+}
+
+type commitData struct {
+	User        *userData
+	Message     string
+	SHA         string
+	ShortSHA    string
+	URL         string
+	CommittedAt string
+	Dropped     bool
+}
+
+type commentData struct {
+	User      *userData
+	Action    string
+	CreatedAt string
+	Timestamp string
+	Body      string
+	Reactions reactionsData
+}
+
+type reactionsData struct {
+	Reactions []*reactionData
+	ShowUsers bool
+}
+
+type reactionData struct {
+	Content string
+	Emoji   string
+	Count   int
+	Users   []string
+}
+
+type eventsData struct {
+	Actions []string
+	Last    int
+}
+
+type eventData struct {
+	Event          string
+	Type           string
+	PullRequest    *pullRequestData
+	CommitURL      string
+	ShortSHA       string
+	Dropped        bool
+	From, To       string
+	Ref            string
+	LockReason     string
+	Users          []string
+	Team           string
+	Reviewer       string
+	Message        string
+	Project        *projectData
+	Column         string
+	PreviousColumn string
+	Milestone      *milestoneData
+	Added, Removed []string
+	Count          int
+}
+
+type projectData struct {
+	Name string
+	URL  string
+}
+
+type milestoneData struct {
+	Title string
+	URL   string
+}
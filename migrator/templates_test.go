@@ -0,0 +1,86 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mentionAsIs(name string) string {
+	return "@" + name
+}
+
+func TestLoadTemplates(t *testing.T) {
+	tmpl, err := loadTemplates("", mentionAsIs)
+	require.NoError(t, err)
+	got, err := executeTemplate(tmpl, "comment", &commentData{
+		User:      &userData{Login: "sample-user", AvatarURL: "https://github.com/sample-user.png"},
+		Action:    "commented",
+		Body:      "Example body",
+		Reactions: reactionsData{Reactions: []*reactionData{{Emoji: "👍", Count: 1}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<table>
+<tr>
+  <td width="60">
+    <img src="https://github.com/sample-user.png" width="35">
+  </td>
+  <td>
+    @sample-user commented
+  </td>
+</tr>
+</table>
+
+
+Example body
+
+👍 1`, got)
+}
+
+func TestLoadTemplatesOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "custom.tmpl"), []byte(`
+{{- define "avatar" }}{{ "" }}{{ end -}}
+{{- define "event_closed" }}hat das Issue geschlossen{{ end -}}
+`), 0644))
+	tmpl, err := loadTemplates(dir, mentionAsIs)
+	require.NoError(t, err)
+	got, err := executeTemplate(tmpl, "comment", &commentData{
+		User:      &userData{Login: "sample-user", AvatarURL: "https://github.com/sample-user.png"},
+		Action:    "commented",
+		CreatedAt: "2020-01-02T03:04:05Z",
+		Timestamp: " on Jan 2, 2020, 03:04 UTC",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<table>
+<tr>
+  <td colspan="2">
+    @sample-user commented on Jan 2, 2020, 03:04 UTC
+  </td>
+</tr>
+</table>
+`, got)
+	got, err = executeTemplate(tmpl, "events", &eventsData{
+		Actions: []string{"closed the issue", "locked the issue", "pinned the issue"},
+		Last:    2,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "closed the issue, locked the issue and pinned the issue", got)
+	got, err = executeTemplate(tmpl, "event_closed", &eventData{})
+	require.NoError(t, err)
+	assert.Equal(t, "hat das Issue geschlossen", got)
+}
+
+func TestLoadTemplatesError(t *testing.T) {
+	dir := t.TempDir()
+	_, err := loadTemplates(dir, mentionAsIs)
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "custom.tmpl"), []byte(`{{ define "comment" }}{{ .Unknown }}{{ end }}`), 0644))
+	tmpl, err := loadTemplates(dir, mentionAsIs)
+	require.NoError(t, err)
+	_, err = executeTemplate(tmpl, "comment", &commentData{})
+	assert.Error(t, err)
+}
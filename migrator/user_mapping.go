@@ -0,0 +1,152 @@
+package migrator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UserMappingEntry is an entry of the user mapping file. The fields other than
+// the source and the target are optional, for the reviewers of the mapping.
+// The entries without the target are ignored.
+type UserMappingEntry struct {
+	Source     string   `yaml:"source"`
+	Target     string   `yaml:"target"`
+	Email      string   `yaml:"email,omitempty"`
+	Name       string   `yaml:"name,omitempty"`
+	Confidence float64  `yaml:"confidence,omitempty"`
+	Reason     string   `yaml:"reason,omitempty"`
+	Candidates []string `yaml:"candidates,omitempty"`
+}
+
+// UserMapping is the list of user mapping entries.
+type UserMapping []*UserMappingEntry
+
+// LoadUserMapping loads the user mapping file. The format is detected from
+// the extension of the file; the CSV file (.csv) has the columns of source,
+// target, email, name, confidence and reason (the header line is optional),
+// and the YAML file (.yaml or .yml) is a list of the entries.
+func LoadUserMapping(path string) (UserMapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var um UserMapping
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		um, err = readUserMappingCSV(f)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err = dec.Decode(&um); err == io.EOF {
+			err = nil
+		}
+	default:
+		err = fmt.Errorf("unknown user mapping format: %q (specify .csv, .yaml or .yml)", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err = um.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return um, nil
+}
+
+func readUserMappingCSV(r io.Reader) (UserMapping, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	var um UserMapping
+	for i := 0; ; i++ {
+		xs, err := cr.Read()
+		if err != nil {
+			if err == io.EOF {
+				return um, nil
+			}
+			return nil, err
+		}
+		if i == 0 && strings.EqualFold(xs[0], "source") {
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		if len(xs) < 2 || len(xs) > len(userMappingCSVHeader) {
+			return nil, fmt.Errorf("line %d: expected %s", line, strings.Join(userMappingCSVHeader, ","))
+		}
+		xs = append(xs, make([]string, len(userMappingCSVHeader)-len(xs))...)
+		e := &UserMappingEntry{Source: xs[0], Target: xs[1], Email: xs[2], Name: xs[3], Reason: xs[5]}
+		if xs[4] != "" {
+			if e.Confidence, err = strconv.ParseFloat(xs[4], 64); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		um = append(um, e)
+	}
+}
+
+var userMappingCSVHeader = []string{"source", "target", "email", "name", "confidence", "reason"}
+
+// Save writes the user mapping file in the format detected from the extension.
+func (um UserMapping) Save(path string) error {
+	var bs []byte
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		s := new(strings.Builder)
+		w := csv.NewWriter(s)
+		w.Write(userMappingCSVHeader)
+		for _, e := range um {
+			var confidence string
+			if e.Confidence > 0 {
+				confidence = strconv.FormatFloat(e.Confidence, 'f', 2, 64)
+			}
+			w.Write([]string{e.Source, e.Target, e.Email, e.Name, confidence, e.Reason})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return err
+		}
+		bs = []byte(s.String())
+	case ".yaml", ".yml":
+		var err error
+		if bs, err = yaml.Marshal(um); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown user mapping format: %q (specify .csv, .yaml or .yml)", ext)
+	}
+	return os.WriteFile(path, bs, 0644)
+}
+
+func (um UserMapping) validate() error {
+	sources := make(map[string]bool, len(um))
+	for i, e := range um {
+		if e.Source == "" {
+			return fmt.Errorf("user mapping %d: specify source", i+1)
+		}
+		if sources[e.Source] {
+			return fmt.Errorf("user mapping %d: duplicate source: %s", i+1, e.Source)
+		}
+		sources[e.Source] = true
+	}
+	return nil
+}
+
+// Map returns the mapping from the logins of the source to the logins of the
+// target.
+func (um UserMapping) Map() map[string]string {
+	m := make(map[string]string, len(um))
+	for _, e := range um {
+		if e.Target != "" {
+			m[e.Source] = e.Target
+		}
+	}
+	return m
+}
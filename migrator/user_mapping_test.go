@@ -0,0 +1,93 @@
+package migrator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadUserMapping(t *testing.T) {
+	dir := t.TempDir()
+	expected := UserMapping{
+		{Source: "sample-user-1", Target: "new-user-1", Email: "user1@example.com", Name: "User One"},
+		{Source: "sample-user-2", Target: "new-user-2"},
+		{Source: "sample-user-3"},
+	}
+
+	csvPath := filepath.Join(dir, "users.csv")
+	assert.Nil(t, os.WriteFile(csvPath, []byte(`source,target,email,name
+# comment
+sample-user-1, new-user-1, user1@example.com, User One
+sample-user-2,new-user-2
+sample-user-3,
+`), 0644))
+	got, err := LoadUserMapping(csvPath)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, got)
+	assert.Equal(t, map[string]string{
+		"sample-user-1": "new-user-1",
+		"sample-user-2": "new-user-2",
+	}, got.Map())
+
+	yamlPath := filepath.Join(dir, "users.yaml")
+	assert.Nil(t, os.WriteFile(yamlPath, []byte(`- source: sample-user-1
+  target: new-user-1
+  email: user1@example.com
+  name: User One
+- source: sample-user-2
+  target: new-user-2
+- source: sample-user-3
+  target: ""
+`), 0644))
+	got, err = LoadUserMapping(yamlPath)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, got)
+}
+
+func TestLoadUserMappingError(t *testing.T) {
+	dir := t.TempDir()
+	testCases := []struct {
+		name, src, err string
+	}{
+		{"users.csv", "sample-user-1\n", "line 1: expected source,target,email,name,confidence,reason"},
+		{"users.csv", "sample-user-1,new-user-1,,,high\n", `line 1: strconv.ParseFloat: parsing "high": invalid syntax`},
+		{"users.csv", "sample-user-1,new-user-1\nsample-user-1,new-user-2\n", "user mapping 2: duplicate source: sample-user-1"},
+		{"users.yaml", "- target: new-user-1\n", "user mapping 1: specify source"},
+		{"users.json", "{}", `unknown user mapping format: ".json" (specify .csv, .yaml or .yml)`},
+	}
+	for _, tc := range testCases {
+		path := filepath.Join(dir, tc.name)
+		assert.Nil(t, os.WriteFile(path, []byte(tc.src), 0644))
+		_, err := LoadUserMapping(path)
+		assert.EqualError(t, err, path+": "+tc.err)
+	}
+}
+
+func TestSaveUserMapping(t *testing.T) {
+	dir := t.TempDir()
+	um := UserMapping{
+		{Source: "sample-user-1", Target: "new-user-1", Name: "User One", Confidence: 0.9, Reason: "name"},
+		{Source: "sample-user-2", Candidates: []string{"new-user-2 (0.40, similar login)"}},
+	}
+
+	csvPath := filepath.Join(dir, "users.csv")
+	assert.Nil(t, um.Save(csvPath))
+	bs, err := os.ReadFile(csvPath)
+	assert.Nil(t, err)
+	assert.Equal(t, `source,target,email,name,confidence,reason
+sample-user-1,new-user-1,,User One,0.90,name
+sample-user-2,,,,,
+`, string(bs))
+	got, err := LoadUserMapping(csvPath)
+	assert.Nil(t, err)
+	assert.Equal(t, UserMapping{um[0], {Source: "sample-user-2"}}, got)
+
+	yamlPath := filepath.Join(dir, "users.yaml")
+	assert.Nil(t, um.Save(yamlPath))
+	got, err = LoadUserMapping(yamlPath)
+	assert.Nil(t, err)
+	assert.Equal(t, um, got)
+	assert.Equal(t, map[string]string{"sample-user-1": "new-user-1"}, got.Map())
+}
@@ -0,0 +1,229 @@
+package migrator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// UserSuggester represents a suggester of the user mapping.
+type UserSuggester interface {
+	SuggestUsers() error
+}
+
+// NewUserSuggester creates a new UserSuggester, which scans the users of the
+// source repository, and suggests the users on the target from the members of
+// the target organization, by the emails, names and logins. The suggestions
+// are saved to the user mapping file with the confidence scores, to be reviewed
+// and loaded for the migration. The users in the user mapping are kept as they
+// are mapped.
+func NewUserSuggester(source, target *repo.Repo, userMapping map[string]string, path string) UserSuggester {
+	return &userSuggester{
+		migrator: &migrator{source: source, target: target, userMapping: userMapping},
+		path:     path,
+	}
+}
+
+type userSuggester struct {
+	*migrator
+	path string
+}
+
+// The confidence scores of the suggestions. The target is filled only for the
+// confident suggestion (by the email or the name), and the others are listed
+// in the candidates to be reviewed.
+const (
+	userSuggestionThreshold = 0.5
+	userSuggestionConfident = 0.9
+	userSuggestionEmail     = 1.0
+	userSuggestionName      = 0.9
+	userSuggestionLogin     = 0.8
+	userSuggestionNameLogin = 0.7
+	userSuggestionSimilar   = 0.7 // multiplied by the similarity
+)
+
+type userCandidate struct {
+	login      string
+	confidence float64
+	reasons    []string
+}
+
+func (c *userCandidate) String() string {
+	return fmt.Sprintf("%s (%.2f, %s)", c.login, c.confidence, strings.Join(c.reasons, ", "))
+}
+
+// SuggestUsers suggests the user mapping.
+func (s *userSuggester) SuggestUsers() (err error) {
+	if s.sourceRepo, err = s.source.Get(); err != nil {
+		return err
+	}
+	if s.targetRepo, err = s.target.Get(); err != nil {
+		return err
+	}
+	if s.targetMembers, err = github.MembersToSlice(s.target.ListMembers()); err != nil {
+		return err
+	}
+	users, err := scanSourceUsers(s.source)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("[|>] fetching the profiles of %s on the target\n", plural(len(s.targetMembers), "member"))
+	members := make([]*github.User, len(s.targetMembers))
+	for i, member := range s.targetMembers {
+		if members[i], err = s.target.GetUser(member.Login); err != nil {
+			return err
+		}
+	}
+	var um UserMapping
+	var mapped, suggested, candidated int
+	for _, name := range users.names() {
+		fmt.Printf("[|>] suggesting the user for @%s\n", name)
+		e := &UserMappingEntry{Source: name}
+		um = append(um, e)
+		user, err := s.source.GetUser(name)
+		if err != nil {
+			if !strings.HasSuffix(err.Error(), "Not Found") {
+				return err
+			}
+			user = &github.User{Login: name}
+		}
+		e.Email, e.Name = user.Email, user.Name
+		if target, ok := s.userMapping[name]; ok {
+			e.Target, e.Confidence, e.Reason = target, 1.0, "mapped"
+			mapped++
+			continue
+		}
+		emails := users.commitEmails[name]
+		if user.Email != "" && !containsString(emails, user.Email) {
+			emails = append([]string{user.Email}, emails...)
+		}
+		if e.Email == "" && len(emails) > 0 {
+			e.Email = emails[0]
+		}
+		candidates := suggestUserCandidates(user, emails, members)
+		if len(candidates) == 0 {
+			fmt.Printf("[!!] no user suggested for @%s (%s)\n", name, users.formatIssueNumbers(name))
+			continue
+		}
+		if len(candidates) > 1 && candidates[0].confidence-candidates[1].confidence < 0.05 {
+			fmt.Printf("[??] ambiguous users for @%s: %s, %s\n", name, candidates[0], candidates[1])
+		} else if candidates[0].confidence >= userSuggestionConfident {
+			suggested++
+			e.Target = candidates[0].login
+			e.Confidence = candidates[0].confidence
+			e.Reason = strings.Join(candidates[0].reasons, ", ")
+			candidates = candidates[1:]
+			fmt.Printf("[<>] suggested user for @%s: %s\n", name, e.Target)
+		} else {
+			fmt.Printf("[??] candidate user for @%s: %s (review and fill in the target)\n", name, candidates[0])
+		}
+		if e.Target == "" {
+			candidated++
+		}
+		for _, c := range candidates {
+			e.Candidates = append(e.Candidates, c.String())
+		}
+	}
+	if err := um.Save(s.path); err != nil {
+		return err
+	}
+	fmt.Printf("[<>] saved the user mapping to %s (%d mapped, %d suggested, %d to review, %d not suggested)\n",
+		s.path, mapped, suggested, candidated, len(um)-mapped-suggested-candidated)
+	return nil
+}
+
+// suggestUserCandidates lists the candidates of the user in the order of the
+// confidence scores.
+func suggestUserCandidates(user *github.User, emails []string, members []*github.User) []*userCandidate {
+	login, name := normalizeUserName(user.Login), normalizeUserName(user.Name)
+	var candidates []*userCandidate
+	for _, member := range members {
+		c := &userCandidate{login: member.Login}
+		add := func(confidence float64, reason string) {
+			if confidence = math.Round(confidence*100) / 100; confidence > c.confidence {
+				c.confidence = confidence
+			}
+			c.reasons = append(c.reasons, reason)
+		}
+		if member.Email != "" {
+			for _, email := range emails {
+				if strings.EqualFold(email, member.Email) {
+					add(userSuggestionEmail, "email")
+					break
+				}
+			}
+		}
+		memberLogin := normalizeUserName(member.Login)
+		if name != "" {
+			if name == normalizeUserName(member.Name) {
+				add(userSuggestionName, "name")
+			} else if name == memberLogin {
+				add(userSuggestionNameLogin, "name matches login")
+			}
+		}
+		if login == memberLogin {
+			add(userSuggestionLogin, "same login")
+		} else if similarity := stringSimilarity(login, memberLogin); similarity >= 0.7 {
+			add(userSuggestionSimilar*similarity, "similar login")
+		}
+		if c.confidence >= userSuggestionThreshold {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].confidence > candidates[j].confidence
+	})
+	return candidates
+}
+
+// normalizeUserName normalizes the name (or login) for comparison, by removing
+// the characters other than the letters and digits.
+func normalizeUserName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// stringSimilarity calculates the similarity of the strings (from 0 to 1) by
+// the Levenshtein distance.
+func stringSimilarity(s, t string) float64 {
+	xs, ys := []rune(s), []rune(t)
+	if len(xs) == 0 || len(ys) == 0 {
+		return 0
+	}
+	ds := make([]int, len(ys)+1)
+	for j := range ds {
+		ds[j] = j
+	}
+	for i := 1; i <= len(xs); i++ {
+		prev := ds[0]
+		ds[0] = i
+		for j := 1; j <= len(ys); j++ {
+			d := prev
+			if xs[i-1] != ys[j-1] {
+				d++
+			}
+			prev = ds[j]
+			if ds[j]+1 < d {
+				d = ds[j] + 1
+			}
+			if ds[j-1]+1 < d {
+				d = ds[j-1] + 1
+			}
+			ds[j] = d
+		}
+	}
+	l := len(xs)
+	if len(ys) > l {
+		l = len(ys)
+	}
+	return 1 - float64(ds[len(ys)])/float64(l)
+}
@@ -0,0 +1,105 @@
+package migrator
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestUserSuggester(t *testing.T) {
+	source := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/test", HTMLURL: "http://localhost/example/test"}, nil
+		}),
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice([]*github.Issue{
+				{
+					Number:      1,
+					User:        &github.User{Login: "sample-user-1"},
+					HTMLURL:     "http://localhost/example/test/pull/1",
+					PullRequest: &github.IssuePullRequest{},
+				},
+			})
+		}),
+		github.MockListComments(func(string, int) github.Comments {
+			return github.CommentsFromSlice([]*github.Comment{
+				{User: &github.User{Login: "sample-user-2"}},
+				{User: &github.User{Login: "octocat"}},
+				{User: &github.User{Login: "sample-user-4"}},
+				{User: &github.User{Login: "deleted-user"}},
+			})
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice([]*github.Event{})
+		}),
+		github.MockListReviews(func(string, int) github.Reviews {
+			return github.ReviewsFromSlice([]*github.Review{})
+		}),
+		github.MockListReviewComments(func(string, int) github.ReviewComments {
+			return github.ReviewCommentsFromSlice([]*github.ReviewComment{})
+		}),
+		github.MockListPullReqCommits(func(string, int) github.Commits {
+			c := &github.Commit{Author: &github.User{Login: "sample-user-1"}}
+			c.Commit.Author = &github.CommitUser{Email: "user1@example.com"}
+			return github.CommitsFromSlice([]*github.Commit{c})
+		}),
+		github.MockGetUser(func(name string) (*github.User, error) {
+			switch name {
+			case "sample-user-2":
+				return &github.User{Login: name, Name: "User Two"}, nil
+			case "deleted-user":
+				return nil, errors.New("GetUser /user/" + name + ": Not Found")
+			default:
+				return &github.User{Login: name}, nil
+			}
+		}),
+	), "example/test")
+	target := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "new-example/test", HTMLURL: "http://localhost/new-example/test"}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice([]*github.Member{
+				{Login: "new-user-1"}, {Login: "new-user-2"}, {Login: "octocat"}, {Login: "octocat2"},
+			})
+		}),
+		github.MockGetUser(func(name string) (*github.User, error) {
+			switch name {
+			case "new-user-1":
+				return &github.User{Login: name, Email: "User1@example.com"}, nil
+			case "new-user-2":
+				return &github.User{Login: name, Name: "user two"}, nil
+			default:
+				return &github.User{Login: name}, nil
+			}
+		}),
+	), "new-example/test")
+
+	path := filepath.Join(t.TempDir(), "users.yaml")
+	s := NewUserSuggester(source, target, map[string]string{"sample-user-4": "new-user-4"}, path)
+	assert.Nil(t, s.SuggestUsers())
+	got, err := LoadUserMapping(path)
+	assert.Nil(t, err)
+	assert.Equal(t, UserMapping{
+		{Source: "deleted-user"},
+		{
+			Source:     "octocat",
+			Candidates: []string{"octocat (0.80, same login)", "octocat2 (0.61, similar login)"},
+		},
+		{Source: "sample-user-1", Target: "new-user-1", Email: "user1@example.com", Confidence: 1, Reason: "email"},
+		{Source: "sample-user-2", Target: "new-user-2", Name: "User Two", Confidence: 0.9, Reason: "name"},
+		{Source: "sample-user-4", Target: "new-user-4", Confidence: 1, Reason: "mapped"},
+	}, got)
+}
+
+func TestStringSimilarity(t *testing.T) {
+	assert.Equal(t, 1.0, stringSimilarity("user", "user"))
+	assert.Equal(t, 0.8, stringSimilarity("users", "user"))
+	assert.Equal(t, 0.75, stringSimilarity("user", "uzer"))
+	assert.Equal(t, 0.0, stringSimilarity("user", ""))
+}
@@ -0,0 +1,112 @@
+package migrator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+// UserValidator represents a validator of the user mapping.
+type UserValidator interface {
+	ValidateUsers() error
+}
+
+// NewUserValidator creates a new UserValidator, which scans the users in the
+// issues, comments, events, reviews and commits of the source repository, and
+// checks that the users (mapped with the user mapping) exist on the target.
+// This is a pre-flight check before the migration, so nothing is written to
+// the target.
+func NewUserValidator(source, target *repo.Repo, userMapping map[string]string) UserValidator {
+	return &userValidator{
+		migrator: &migrator{source: source, target: target, userMapping: userMapping},
+	}
+}
+
+type userValidator struct {
+	*migrator
+	users *sourceUsers
+}
+
+// MigratorValidateUsers returns a migrator option to validate the users before
+// the migration starts. The migration is aborted when the users are not found
+// on the target, or not in the user mapping unless allowUnmapped is set.
+func MigratorValidateUsers(allowUnmapped bool) MigratorOption {
+	return func(m *migrator) {
+		m.validateUsers, m.allowUnmappedUsers = true, allowUnmapped
+	}
+}
+
+// ValidateUsers validates the users.
+func (v *userValidator) ValidateUsers() (err error) {
+	if v.sourceRepo, err = v.source.Get(); err != nil {
+		return err
+	}
+	if v.targetRepo, err = v.target.Get(); err != nil {
+		return err
+	}
+	if v.targetMembers, err = github.MembersToSlice(v.target.ListMembers()); err != nil {
+		return err
+	}
+	if v.users, err = scanSourceUsers(v.source); err != nil {
+		return err
+	}
+	return v.checkUsers(v.users, true)
+}
+
+// preflightUsers validates the users before the migration, when specified.
+func (m *migrator) preflightUsers() error {
+	if !m.validateUsers {
+		return nil
+	}
+	users, err := scanSourceUsers(m.source)
+	if err != nil {
+		return err
+	}
+	return m.checkUsers(users, m.allowUnmappedUsers)
+}
+
+// checkUsers checks that the users (mapped with the user mapping) exist on the
+// target. The unmapped users found on the target are errors unless allowed,
+// since they may be different users.
+func (m *migrator) checkUsers(users *sourceUsers, allowUnmapped bool) error {
+	names := users.names()
+	var mappedCount, missing, unmapped int
+	for _, name := range names {
+		target, mapped := m.userMapping[name]
+		if mapped {
+			mappedCount++
+		} else {
+			target = name
+		}
+		if _, err := m.lookupUser(target); err != nil {
+			if !strings.HasSuffix(err.Error(), "Not Found") {
+				return err
+			}
+			missing++
+			if mapped {
+				fmt.Printf("[!!] mapped user not found on the target: @%s => @%s (%s)\n",
+					name, target, users.formatIssueNumbers(name))
+			} else {
+				fmt.Printf("[!!] unmapped user not found on the target: @%s (%s)\n",
+					name, users.formatIssueNumbers(name))
+			}
+			continue
+		}
+		if !mapped {
+			unmapped++
+			fmt.Printf("[??] unmapped user found on the target (check that it is the same user): @%s (%s)\n",
+				name, users.formatIssueNumbers(name))
+		}
+	}
+	fmt.Printf("[<>] validated %s (%d mapped, %d not found)\n",
+		plural(len(names), "user"), mappedCount, missing)
+	if missing > 0 {
+		return fmt.Errorf("%s not found on the target (update the user mapping)", plural(missing, "user"))
+	}
+	if unmapped > 0 && !allowUnmapped {
+		return fmt.Errorf("%s not in the user mapping (update the user mapping)", plural(unmapped, "user"))
+	}
+	return nil
+}
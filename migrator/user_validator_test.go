@@ -0,0 +1,89 @@
+package migrator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestUserValidator(t *testing.T) {
+	source := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "example/test", HTMLURL: "http://localhost/example/test"}, nil
+		}),
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice([]*github.Issue{
+				{
+					Number:  1,
+					User:    &github.User{Login: "sample-user-1"},
+					HTMLURL: "http://localhost/example/test/issues/1",
+				},
+				{
+					Number:      2,
+					User:        &github.User{Login: "sample-user-2"},
+					HTMLURL:     "http://localhost/example/test/pull/2",
+					PullRequest: &github.IssuePullRequest{},
+				},
+			})
+		}),
+		github.MockListComments(func(string, int) github.Comments {
+			return github.CommentsFromSlice([]*github.Comment{
+				{User: &github.User{Login: "sample-user-3"}},
+			})
+		}),
+		github.MockListEvents(func(string, int) github.Events {
+			return github.EventsFromSlice([]*github.Event{
+				{Actor: &github.User{Login: "ghost"}},
+			})
+		}),
+		github.MockListReviews(func(string, int) github.Reviews {
+			return github.ReviewsFromSlice([]*github.Review{})
+		}),
+		github.MockListReviewComments(func(string, int) github.ReviewComments {
+			return github.ReviewCommentsFromSlice([]*github.ReviewComment{})
+		}),
+		github.MockListPullReqCommits(func(string, int) github.Commits {
+			return github.CommitsFromSlice([]*github.Commit{
+				{Author: &github.User{Login: "sample-user-4"}},
+			})
+		}),
+	), "example/test")
+	target := repo.New(github.NewMockClient(
+		github.MockGetRepo(func(string) (*github.Repo, error) {
+			return &github.Repo{FullName: "new-example/test", HTMLURL: "http://localhost/new-example/test"}, nil
+		}),
+		github.MockListMembers(func(string) github.Members {
+			return github.MembersFromSlice([]*github.Member{{Login: "new-user-1"}})
+		}),
+		github.MockGetUser(func(name string) (*github.User, error) {
+			if name == "sample-user-3" {
+				return &github.User{Login: name}, nil
+			}
+			return nil, errors.New("GetUser /user/" + name + ": Not Found")
+		}),
+	), "new-example/test")
+
+	v := NewUserValidator(source, target, map[string]string{
+		"sample-user-1": "new-user-1",
+		"sample-user-2": "new-user-2",
+	})
+	assert.EqualError(t, v.ValidateUsers(), "2 users not found on the target (update the user mapping)")
+	assert.Equal(t, map[string][]int{
+		"sample-user-1": {1},
+		"sample-user-2": {2},
+		"sample-user-3": {1, 2},
+		"sample-user-4": {2},
+	}, v.(*userValidator).users.issueNumbers)
+
+	// the unmapped users are not allowed before the migration
+	m := New(source, target, map[string]string{
+		"sample-user-1": "new-user-1",
+		"sample-user-2": "new-user-1",
+		"sample-user-4": "new-user-1",
+	}, MigratorValidateUsers(false))
+	assert.EqualError(t, m.Migrate(), "1 user not in the user mapping (update the user mapping)")
+}
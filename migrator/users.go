@@ -6,6 +6,15 @@ import (
 	"github.com/itchyny/github-migrator/github"
 )
 
+// mapLogin maps the login of the user with the user mapping. The logins are not
+// passed through the comment filters, which rewrite the text.
+func (m *migrator) mapLogin(login string) string {
+	if target, ok := m.userMapping[login]; ok {
+		return target
+	}
+	return login
+}
+
 func (m *migrator) isTargetMember(name string) (bool, error) {
 	if strings.HasPrefix(m.targetRepo.FullName, name+"/") {
 		return true, nil
@@ -0,0 +1,354 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+var waitImportInitialDuration = 1 * time.Second
+
+const stateFileName = "state.json"
+
+// state is saved in the directory to resume when applying is interrupted.
+// The numbers of the created issues and the ids of the imports are saved even
+// when the operations fail, so that the issues are not created twice.
+type state struct {
+	Applied map[string]bool `json:"applied"`
+	IDs     map[int]int     `json:"ids"`
+	Numbers map[int]int     `json:"numbers,omitempty"`
+	Imports map[int]int     `json:"imports,omitempty"`
+}
+
+// Apply applies the operations in the directory to the target repository.
+// The operations are read from the files on applying, so the files edited
+// after building are respected. The created numbers of the issues, milestones
+// and projects are checked since the import payloads refer to the numbers.
+func Apply(dir string, target *repo.Repo) error {
+	names, ops, err := readOperations(dir)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return fmt.Errorf("no operations found: %s", dir)
+	}
+	a := &applier{target: target, statePath: filepath.Join(dir, stateFileName)}
+	if err := a.readState(); err != nil {
+		return err
+	}
+	for i, op := range ops {
+		name := filepath.Base(names[i])
+		if a.state.Applied[name] {
+			fmt.Printf("[--] skipping: %s (already applied)\n", name)
+			continue
+		}
+		fmt.Printf("[>>] applying: %s\n", name)
+		if err := a.apply(op); err != nil {
+			if err := a.writeState(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		a.state.Applied[name] = true
+		if err := a.writeState(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type applier struct {
+	target    *repo.Repo
+	statePath string
+	state     *state
+}
+
+func (a *applier) readState() error {
+	a.state = &state{
+		Applied: make(map[string]bool),
+		IDs:     make(map[int]int),
+		Numbers: make(map[int]int),
+		Imports: make(map[int]int),
+	}
+	bs, err := os.ReadFile(a.statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return json.Unmarshal(bs, a.state)
+}
+
+func (a *applier) writeState() error {
+	bs, err := json.MarshalIndent(a.state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(a.statePath, append(bs, '\n'), 0644)
+}
+
+// id resolves the temporary id of the object created in the plan.
+func (a *applier) id(id int) (int, error) {
+	if id >= 0 {
+		return id, nil
+	}
+	if x, ok := a.state.IDs[id]; ok {
+		return x, nil
+	}
+	return 0, fmt.Errorf("unknown id: %d", id)
+}
+
+func checkNumber(kind string, expected, got int) error {
+	if expected != got {
+		return fmt.Errorf("unexpected %s number: %d (expected %d)", kind, got, expected)
+	}
+	return nil
+}
+
+func (a *applier) apply(op *Operation) (err error) {
+	decode := func(v interface{}) error {
+		return json.Unmarshal(op.Params, v)
+	}
+	var id int
+	if id, err = a.id(op.ID); err != nil {
+		return err
+	}
+	switch op.Method {
+	case "UpdateRepo":
+		var params github.UpdateRepoParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.Update(&params)
+		}
+	case "CreateLabel":
+		var params github.CreateLabelParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.CreateLabel(&params)
+		}
+	case "UpdateLabel":
+		var params github.UpdateLabelParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.UpdateLabel(op.Name, &params)
+		}
+	case "DeleteLabel":
+		err = a.target.DeleteLabel(op.Name)
+	case "CreateIssue":
+		var params github.CreateIssueParams
+		if err = decode(&params); err == nil {
+			var issue *github.Issue
+			if issue, err = a.lookupIssue(op, params.Title); err == nil && issue == nil {
+				issue, err = a.target.CreateIssue(&params)
+			}
+			if err == nil {
+				a.state.IDs[op.Result], a.state.Numbers[op.Result] = issue.ID, issue.Number
+				err = checkNumber("issue", op.Number, issue.Number)
+			}
+		}
+	case "UpdateIssue":
+		var params github.UpdateIssueParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.UpdateIssue(op.Number, &params)
+		}
+	case "AddAssignees":
+		var assignees []string
+		if err = decode(&assignees); err == nil {
+			err = a.target.AddAssignees(op.Number, assignees)
+		}
+	case "CreateComment":
+		var params struct {
+			Body string `json:"body"`
+		}
+		if err = decode(&params); err == nil {
+			_, err = a.target.CreateComment(op.Number, params.Body)
+		}
+	case "Import":
+		var params github.Import
+		if err = decode(&params); err == nil {
+			err = a.importIssue(op, &params)
+		}
+	case "CreateProject":
+		var params github.CreateProjectParams
+		if err = decode(&params); err == nil {
+			var project *github.Project
+			if project, err = a.target.CreateProject(&params); err == nil {
+				a.state.IDs[op.Result] = project.ID
+				err = checkNumber("project", op.Number, project.Number)
+			}
+		}
+	case "UpdateProject":
+		var params github.UpdateProjectParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.UpdateProject(id, &params)
+		}
+	case "DeleteProject":
+		err = a.target.DeleteProject(id)
+	case "CreateProjectColumn", "UpdateProjectColumn":
+		var params struct {
+			Name string `json:"name"`
+		}
+		if err = decode(&params); err == nil {
+			if op.Method == "UpdateProjectColumn" {
+				_, err = a.target.UpdateProjectColumn(id, params.Name)
+				break
+			}
+			var column *github.ProjectColumn
+			if column, err = a.target.CreateProjectColumn(id, params.Name); err == nil {
+				a.state.IDs[op.Result] = column.ID
+			}
+		}
+	case "CreateProjectCard":
+		var params github.CreateProjectCardParams
+		if err = decode(&params); err == nil {
+			if params.ContentID, err = a.id(params.ContentID); err == nil {
+				var card *github.ProjectCard
+				if card, err = a.target.CreateProjectCard(id, &params); err == nil {
+					a.state.IDs[op.Result] = card.ID
+				}
+			}
+		}
+	case "UpdateProjectCard":
+		var params github.UpdateProjectCardParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.UpdateProjectCard(id, &params)
+		}
+	case "MoveProjectCard":
+		var params github.MoveProjectCardParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.MoveProjectCard(id, &params)
+		}
+	case "CreateMilestone":
+		var params github.CreateMilestoneParams
+		if err = decode(&params); err == nil {
+			var milestone *github.Milestone
+			if milestone, err = a.target.CreateMilestone(&params); err == nil {
+				err = checkNumber("milestone", op.Number, milestone.Number)
+			}
+		}
+	case "UpdateMilestone":
+		var params github.UpdateMilestoneParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.UpdateMilestone(op.Number, &params)
+		}
+	case "DeleteMilestone":
+		err = a.target.DeleteMilestone(op.Number)
+	case "CreateHook":
+		var params github.CreateHookParams
+		if err = decode(&params); err == nil {
+			var hook *github.Hook
+			if hook, err = a.target.CreateHook(&params); err == nil {
+				a.state.IDs[op.Result] = hook.ID
+			}
+		}
+	case "UpdateHook":
+		var params github.UpdateHookParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.UpdateHook(id, &params)
+		}
+	case "CreateFile":
+		var params github.CreateFileParams
+		if err = decode(&params); err == nil {
+			_, err = a.target.NewPath(op.Repo).CreateFile(op.Name, &params)
+		}
+	default:
+		err = fmt.Errorf("unknown method: %s", op.Method)
+	}
+	return err
+}
+
+// lookupIssue looks up the issue created by the interrupted run, by the number
+// saved in the state or the expected number. The issue should have the title.
+func (a *applier) lookupIssue(op *Operation, title string) (*github.Issue, error) {
+	number, ok := a.state.Numbers[op.Result]
+	if !ok {
+		number = op.Number
+	}
+	issue, err := a.target.GetIssue(number)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "Not Found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if issue.Title != title {
+		return nil, fmt.Errorf("unexpected issue #%d: %s (expected %s)", number, issue.Title, title)
+	}
+	fmt.Printf("[--] skipping: #%d (already created)\n", number)
+	return issue, nil
+}
+
+// importIssue imports the issue and waits for the completion, retrying without
+// the assignee when the assignee is not accepted. The issue is looked up by the
+// number to check the number and to save the id.
+func (a *applier) importIssue(op *Operation, params *github.Import) error {
+	issue, err := a.lookupIssue(op, params.Issue.Title)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		if err := a.waitImport(op, params); err != nil {
+			if !strings.Contains(err.Error(), "Issue.assignee") || params.Issue.Assignee == "" {
+				return err
+			}
+			fmt.Printf("[!!] retrying without the assignee: %s (%s)\n", params.Issue.Assignee, err)
+			params.Issue.Assignee = ""
+			if err := a.waitImport(op, params); err != nil {
+				return err
+			}
+		}
+		if issue, err = a.target.GetIssue(op.Number); err != nil {
+			return err
+		}
+		if issue.Title != params.Issue.Title {
+			return fmt.Errorf("unexpected issue #%d: %s (expected %s)", op.Number, issue.Title, params.Issue.Title)
+		}
+	}
+	a.state.IDs[op.Result] = issue.ID
+	return nil
+}
+
+// waitImport imports the issue, or checks the import of the interrupted run,
+// and waits for the completion. The id of the import is saved in the state
+// until it fails.
+func (a *applier) waitImport(op *Operation, params *github.Import) error {
+	var res *github.ImportResult
+	var err error
+	if id, ok := a.state.Imports[op.Result]; ok {
+		res, err = a.target.GetImport(id)
+	} else if res, err = a.target.Import(params); err == nil {
+		a.state.Imports[op.Result] = res.ID
+		err = a.writeState()
+	}
+	if err != nil {
+		return err
+	}
+	var retry int
+	duration := waitImportInitialDuration
+	for res.Status != "imported" {
+		if res.Status == "failed" {
+			delete(a.state.Imports, op.Result)
+			if len(res.Errors) != 0 {
+				return fmt.Errorf("failed status: %w", res.Errors)
+			}
+			return errors.New("failed status")
+		}
+		if retry++; retry > 60 {
+			return errors.New("reached maximum retry count")
+		}
+		fmt.Printf("[??] checking status: %s\n", res.Status)
+		time.Sleep(duration)
+		if duration < 10*time.Second {
+			duration *= 2
+		}
+		if res, err = a.target.GetImport(res.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
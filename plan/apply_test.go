@@ -0,0 +1,155 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func init() {
+	waitImportInitialDuration = 0
+}
+
+func TestApply(t *testing.T) {
+	dir := t.TempDir()
+	for i, op := range []*Operation{
+		{Method: "CreateLabel", Params: []byte(`{"name":"bug","color":"fc2929"}`)},
+		{Method: "DeleteLabel", Name: "good first issue"},
+		{Method: "Import", Number: 4, Result: -1, Params: []byte(`{"issue":{"title":"Edited title","body":"","created_at":"2020-01-01T00:00:00Z","closed":false}}`)},
+		{Method: "CreateProject", Number: 1, Result: -2, Params: []byte(`{"name":"Project","body":""}`)},
+		{Method: "CreateProjectColumn", ID: -2, Result: -3, Params: []byte(`{"name":"To do"}`)},
+		{Method: "CreateProjectCard", ID: -3, Result: -4, Params: []byte(`{"content_id":-1,"content_type":"Issue"}`)},
+	} {
+		assert.Nil(t, writeOperation(filepath.Join(dir, op.fileName(i+1)), op))
+	}
+
+	var labels, deletedLabels []string
+	var imports []*github.Import
+	var cards []*github.CreateProjectCardParams
+	var cardColumnIDs []int
+	target := repo.New(github.NewMockClient(
+		github.MockCreateLabel(func(_ string, params *github.CreateLabelParams) (*github.Label, error) {
+			labels = append(labels, params.Name)
+			return &github.Label{Name: params.Name}, nil
+		}),
+		github.MockDeleteLabel(func(_, name string) error {
+			deletedLabels = append(deletedLabels, name)
+			return nil
+		}),
+		github.MockImport(func(_ string, params *github.Import) (*github.ImportResult, error) {
+			imports = append(imports, params)
+			return &github.ImportResult{ID: 10, Status: "pending"}, nil
+		}),
+		github.MockGetImport(func(string, int) (*github.ImportResult, error) {
+			return &github.ImportResult{ID: 10, Status: "imported"}, nil
+		}),
+		github.MockGetIssue(func(_ string, number int) (*github.Issue, error) {
+			if len(imports) == 0 {
+				return nil, errors.New("Not Found")
+			}
+			return &github.Issue{ID: 400, Number: number, Title: "Edited title"}, nil
+		}),
+		github.MockCreateProject(func(_ string, params *github.CreateProjectParams) (*github.Project, error) {
+			return &github.Project{ID: 100, Number: 1, Name: params.Name}, nil
+		}),
+		github.MockCreateProjectColumn(func(projectID int, name string) (*github.ProjectColumn, error) {
+			assert.Equal(t, 100, projectID)
+			return &github.ProjectColumn{ID: 200, Name: name}, nil
+		}),
+		github.MockCreateProjectCard(func(columnID int, params *github.CreateProjectCardParams) (*github.ProjectCard, error) {
+			cardColumnIDs = append(cardColumnIDs, columnID)
+			cards = append(cards, params)
+			return &github.ProjectCard{ID: 300}, nil
+		}),
+	), "example/test")
+
+	assert.Nil(t, Apply(dir, target))
+	assert.Equal(t, []string{"bug"}, labels)
+	assert.Equal(t, []string{"good first issue"}, deletedLabels)
+	assert.Equal(t, "Edited title", imports[0].Issue.Title)
+	assert.Equal(t, []int{200}, cardColumnIDs)
+	assert.Equal(t, 400, cards[0].ContentID)
+
+	// applied operations are skipped on the next run
+	assert.Nil(t, Apply(dir, target))
+	assert.Equal(t, []string{"bug"}, labels)
+	assert.Len(t, imports, 1)
+	_, err := os.Stat(filepath.Join(dir, stateFileName))
+	assert.Nil(t, err)
+}
+
+func TestApplyUnexpectedNumber(t *testing.T) {
+	dir := t.TempDir()
+	op := &Operation{Method: "CreateMilestone", Number: 2, Params: []byte(`{"title":"v2.0"}`)}
+	assert.Nil(t, writeOperation(filepath.Join(dir, op.fileName(1)), op))
+	target := repo.New(github.NewMockClient(
+		github.MockCreateMilestone(func(string, *github.CreateMilestoneParams) (*github.Milestone, error) {
+			return &github.Milestone{Number: 3}, nil
+		}),
+	), "example/test")
+	assert.EqualError(t, Apply(dir, target),
+		"00001-createmilestone-2.json: unexpected milestone number: 3 (expected 2)")
+}
+
+func TestApplyImportAssignee(t *testing.T) {
+	dir := t.TempDir()
+	op := &Operation{Method: "Import", Number: 1, Result: -1, Params: []byte(`{"issue":{"title":"Example title","body":"","created_at":"2020-01-01T00:00:00Z","closed":false,"assignee":"sample-user-1"}}`)}
+	assert.Nil(t, writeOperation(filepath.Join(dir, op.fileName(1)), op))
+	var imports []*github.Import
+	target := repo.New(github.NewMockClient(
+		github.MockImport(func(_ string, params *github.Import) (*github.ImportResult, error) {
+			imports = append(imports, params)
+			return &github.ImportResult{ID: len(imports), Status: "pending"}, nil
+		}),
+		github.MockGetImport(func(_ string, id int) (*github.ImportResult, error) {
+			if id == 1 {
+				var res github.ImportResult
+				err := json.Unmarshal([]byte(`{"id":1,"status":"failed","errors":[{"resource":"Issue","code":"invalid","field":"assignee","value":"sample-user-1"}]}`), &res)
+				return &res, err
+			}
+			return &github.ImportResult{ID: id, Status: "imported"}, nil
+		}),
+		github.MockGetIssue(func(_ string, number int) (*github.Issue, error) {
+			if len(imports) < 2 {
+				return nil, errors.New("Not Found")
+			}
+			return &github.Issue{ID: 100, Number: number, Title: "Example title"}, nil
+		}),
+	), "example/test")
+	assert.Nil(t, Apply(dir, target))
+	assert.Len(t, imports, 2)
+	assert.Equal(t, "", imports[1].Issue.Assignee)
+}
+
+func TestApplyResumeCreatedIssue(t *testing.T) {
+	dir := t.TempDir()
+	op := &Operation{Method: "CreateIssue", Number: 2, Result: -1, Params: []byte(`{"title":"Example title"}`)}
+	assert.Nil(t, writeOperation(filepath.Join(dir, op.fileName(1)), op))
+	var created int
+	target := repo.New(github.NewMockClient(
+		github.MockCreateIssue(func(string, *github.CreateIssueParams) (*github.Issue, error) {
+			created++
+			return &github.Issue{ID: 300, Number: 3, Title: "Example title"}, nil
+		}),
+		github.MockGetIssue(func(_ string, number int) (*github.Issue, error) {
+			if created == 0 || number != 3 {
+				return nil, errors.New("Not Found")
+			}
+			return &github.Issue{ID: 300, Number: 3, Title: "Example title"}, nil
+		}),
+	), "example/test")
+	assert.EqualError(t, Apply(dir, target),
+		"00001-createissue-2.json: unexpected issue number: 3 (expected 2)")
+
+	// the created issue is looked up by the number saved in the state
+	assert.EqualError(t, Apply(dir, target),
+		"00001-createissue-2.json: unexpected issue number: 3 (expected 2)")
+	assert.Equal(t, 1, created)
+}
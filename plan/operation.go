@@ -0,0 +1,63 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Operation represents a write operation to the target repository.
+// The ids of the objects created in the plan are negative, which are replaced
+// with the actual ids when the operations are applied.
+type Operation struct {
+	Method string          `json:"method"`
+	Repo   string          `json:"repo,omitempty"`
+	Name   string          `json:"name,omitempty"`
+	Number int             `json:"number,omitempty"`
+	ID     int             `json:"id,omitempty"`
+	Result int             `json:"result,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// fileName returns the file name of the operation, the numbers are padded
+// so that the files are sorted in the order of the operations.
+func (op *Operation) fileName(index int) string {
+	name := fmt.Sprintf("%05d-%s", index, strings.ToLower(op.Method))
+	if op.Number > 0 {
+		name += fmt.Sprintf("-%d", op.Number)
+	}
+	return name + ".json"
+}
+
+func writeOperation(path string, op *Operation) error {
+	bs, err := json.MarshalIndent(op, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0644)
+}
+
+// readOperations reads the operations in the directory, in the order of names.
+func readOperations(dir string) ([]string, []*Operation, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "[0-9]*-*.json"))
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(names)
+	ops := make([]*Operation, len(names))
+	for i, name := range names {
+		bs, err := os.ReadFile(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		var op Operation
+		if err := json.Unmarshal(bs, &op); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		ops[i] = &op
+	}
+	return names, ops, nil
+}
@@ -0,0 +1,246 @@
+package plan
+
+import "github.com/itchyny/github-migrator/github"
+
+// The read operations are sent to the target. The results are modified with
+// the recorded operations when the migrator reads them after writing.
+
+func (r *recorder) GetLogin() (*github.User, error) {
+	return r.target.GetLogin()
+}
+
+func (r *recorder) ListUsers() github.Users {
+	return r.target.ListUsers()
+}
+
+func (r *recorder) GetUser(name string) (*github.User, error) {
+	return r.target.GetUser(name)
+}
+
+func (r *recorder) ListMembers(org string) github.Members {
+	return r.target.ListMembers(org)
+}
+
+func (r *recorder) ListTeams(org string) github.Teams {
+	return r.target.ListTeams(org)
+}
+
+func (r *recorder) GetTeam(org, slug string) (*github.Team, error) {
+	return r.target.GetTeam(org, slug)
+}
+
+func (r *recorder) GetRepo(repo string) (*github.Repo, error) {
+	return r.target.GetRepo(repo)
+}
+
+func (r *recorder) ListLabels(repo string) github.Labels {
+	return r.target.ListLabels(repo)
+}
+
+func (r *recorder) ListIssues(repo string, params *github.ListIssuesParams) github.Issues {
+	return r.target.ListIssues(repo, params)
+}
+
+func (r *recorder) GetIssue(repo string, issueNumber int) (*github.Issue, error) {
+	r.mu.Lock()
+	issue, ok := r.issues[issueNumber]
+	r.mu.Unlock()
+	if ok {
+		return issue, nil
+	}
+	return r.target.GetIssue(repo, issueNumber)
+}
+
+func (r *recorder) ListComments(repo string, issueNumber int) github.Comments {
+	return r.target.ListComments(repo, issueNumber)
+}
+
+func (r *recorder) ListAssignees(repo string) github.Users {
+	return r.target.ListAssignees(repo)
+}
+
+func (r *recorder) ListEvents(repo string, issueNumber int) github.Events {
+	return r.target.ListEvents(repo, issueNumber)
+}
+
+func (r *recorder) ListIssueReactions(repo string, issueNumber int) github.Reactions {
+	return r.target.ListIssueReactions(repo, issueNumber)
+}
+
+func (r *recorder) ListCommentReactions(repo string, commentID int) github.Reactions {
+	return r.target.ListCommentReactions(repo, commentID)
+}
+
+func (r *recorder) ListReviewCommentReactions(repo string, commentID int) github.Reactions {
+	return r.target.ListReviewCommentReactions(repo, commentID)
+}
+
+func (r *recorder) ListPullReqs(repo string, params *github.ListPullReqsParams) github.PullReqs {
+	return r.target.ListPullReqs(repo, params)
+}
+
+func (r *recorder) GetPullReq(repo string, pullNumber int) (*github.PullReq, error) {
+	return r.target.GetPullReq(repo, pullNumber)
+}
+
+func (r *recorder) ListPullReqCommits(repo string, pullNumber int) github.Commits {
+	return r.target.ListPullReqCommits(repo, pullNumber)
+}
+
+func (r *recorder) GetDiff(repo, sha string) (string, error) {
+	return r.target.GetDiff(repo, sha)
+}
+
+func (r *recorder) GetCompare(repo, base, head string) (string, error) {
+	return r.target.GetCompare(repo, base, head)
+}
+
+func (r *recorder) RenderMarkdown(repo, text string) (string, error) {
+	return r.target.RenderMarkdown(repo, text)
+}
+
+func (r *recorder) ListReviews(repo string, pullNumber int) github.Reviews {
+	return r.target.ListReviews(repo, pullNumber)
+}
+
+func (r *recorder) GetReview(repo string, pullNumber, reviewID int) (*github.Review, error) {
+	return r.target.GetReview(repo, pullNumber, reviewID)
+}
+
+func (r *recorder) ListReviewComments(repo string, pullNumber int) github.ReviewComments {
+	return r.target.ListReviewComments(repo, pullNumber)
+}
+
+func (r *recorder) ListProjects(repo string, params *github.ListProjectsParams) github.Projects {
+	projects, err := github.ProjectsToSlice(r.target.ListProjects(repo, params))
+	if err != nil {
+		ps := make(chan interface{}, 1)
+		ps <- err
+		close(ps)
+		return github.Projects(ps)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	xs := make([]*github.Project, 0, len(projects)+len(r.projectIDs))
+	for _, p := range projects {
+		if !r.deletedProjects[p.ID] {
+			xs = append(xs, p)
+		}
+	}
+	for _, id := range r.projectIDs {
+		if !r.deletedProjects[id] {
+			xs = append(xs, r.projects[id])
+		}
+	}
+	return github.ProjectsFromSlice(xs)
+}
+
+func (r *recorder) GetProject(projectID int) (*github.Project, error) {
+	r.mu.Lock()
+	project, ok := r.projects[projectID]
+	r.mu.Unlock()
+	if ok {
+		return project, nil
+	}
+	return r.target.GetProject(projectID)
+}
+
+func (r *recorder) ListProjectColumns(projectID int) github.ProjectColumns {
+	var columns []*github.ProjectColumn
+	if projectID > 0 {
+		var err error
+		if columns, err = github.ProjectColumnsToSlice(r.target.ListProjectColumns(projectID)); err != nil {
+			cs := make(chan interface{}, 1)
+			cs <- err
+			close(cs)
+			return github.ProjectColumns(cs)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return github.ProjectColumnsFromSlice(append(columns, r.columns[projectID]...))
+}
+
+func (r *recorder) GetProjectColumn(projectColumnID int) (*github.ProjectColumn, error) {
+	r.mu.Lock()
+	for _, cs := range r.columns {
+		for _, c := range cs {
+			if c.ID == projectColumnID {
+				r.mu.Unlock()
+				return c, nil
+			}
+		}
+	}
+	r.mu.Unlock()
+	return r.target.GetProjectColumn(projectColumnID)
+}
+
+func (r *recorder) ListProjectCards(columnID int) github.ProjectCards {
+	if columnID < 0 {
+		return github.ProjectCardsFromSlice([]*github.ProjectCard{})
+	}
+	return r.target.ListProjectCards(columnID)
+}
+
+func (r *recorder) GetProjectCard(projectCardID int) (*github.ProjectCard, error) {
+	return r.target.GetProjectCard(projectCardID)
+}
+
+func (r *recorder) ListMilestones(repo string, params *github.ListMilestonesParams) github.Milestones {
+	milestones, err := github.MilestonesToSlice(r.target.ListMilestones(repo, params))
+	if err != nil {
+		ms := make(chan interface{}, 1)
+		ms <- err
+		close(ms)
+		return github.Milestones(ms)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	xs := make([]*github.Milestone, 0, len(milestones)+len(r.milestones))
+	for _, m := range milestones {
+		if r.deletedMilestones[m.Number] {
+			continue
+		}
+		if n, ok := r.milestones[m.Number]; ok {
+			m = n
+		}
+		xs = append(xs, m)
+	}
+	for _, number := range r.milestoneNumbers {
+		if !r.deletedMilestones[number] {
+			xs = append(xs, r.milestones[number])
+		}
+	}
+	return github.MilestonesFromSlice(xs)
+}
+
+func (r *recorder) GetMilestone(repo string, milestoneNumber int) (*github.Milestone, error) {
+	r.mu.Lock()
+	milestone, ok := r.milestones[milestoneNumber]
+	r.mu.Unlock()
+	if ok {
+		return milestone, nil
+	}
+	return r.target.GetMilestone(repo, milestoneNumber)
+}
+
+func (r *recorder) ListHooks(repo string) github.Hooks {
+	return r.target.ListHooks(repo)
+}
+
+func (r *recorder) GetHook(repo string, hookID int) (*github.Hook, error) {
+	return r.target.GetHook(repo, hookID)
+}
+
+// GetImport returns imported for the recorded imports. The assignees rejected
+// by the target are dropped on applying.
+func (r *recorder) GetImport(repo string, id int) (*github.ImportResult, error) {
+	if id < 0 {
+		return &github.ImportResult{ID: id, Status: "imported"}, nil
+	}
+	return r.target.GetImport(repo, id)
+}
+
+func (r *recorder) Download(url string) ([]byte, error) {
+	return r.target.Download(url)
+}
@@ -0,0 +1,417 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// NewRecorder creates a client which records the write operations to the
+// directory instead of sending them to the target. The read operations are
+// sent to the target, with the recorded operations reflected so that the
+// migrator proceeds as if the operations were done.
+func NewRecorder(target github.Client, dir string) (github.Client, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	if names, _, err := readOperations(dir); err != nil {
+		return nil, err
+	} else if len(names) > 0 {
+		return nil, fmt.Errorf("directory is not empty: %s", dir)
+	}
+	return &recorder{
+		target:            target,
+		dir:               dir,
+		issues:            make(map[int]*github.Issue),
+		milestones:        make(map[int]*github.Milestone),
+		deletedMilestones: make(map[int]bool),
+		projects:          make(map[int]*github.Project),
+		deletedProjects:   make(map[int]bool),
+		columns:           make(map[int][]*github.ProjectColumn),
+	}, nil
+}
+
+type recorder struct {
+	target github.Client
+	dir    string
+
+	mu                  sync.Mutex
+	count, lastID       int
+	nextIssueNumber     int
+	nextMilestoneNumber int
+	nextProjectNumber   int
+	issues              map[int]*github.Issue
+	milestones          map[int]*github.Milestone
+	milestoneNumbers    []int
+	deletedMilestones   map[int]bool
+	projects            map[int]*github.Project
+	projectIDs          []int
+	deletedProjects     map[int]bool
+	columns             map[int][]*github.ProjectColumn
+}
+
+// record writes the operation to the directory.
+func (r *recorder) record(op *Operation, params interface{}) error {
+	if params != nil {
+		bs, err := json.MarshalIndent(params, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		op.Params = bs
+	}
+	r.count++
+	name := op.fileName(r.count)
+	fmt.Printf("[<>] recording: %s\n", name)
+	if err := writeOperation(filepath.Join(r.dir, name), op); err != nil {
+		return fmt.Errorf("%s %s: %w", op.Method, name, err)
+	}
+	return nil
+}
+
+// newID returns a temporary id of the created object.
+func (r *recorder) newID() int {
+	r.lastID--
+	return r.lastID
+}
+
+// issueNumber predicts the number of the next issue.
+func (r *recorder) issueNumber(repo string) (int, error) {
+	if r.nextIssueNumber == 0 {
+		issue, err := r.target.ListIssues(repo, &github.ListIssuesParams{
+			Filter:    github.ListIssuesParamFilterAll,
+			State:     github.ListIssuesParamStateAll,
+			Sort:      github.ListIssuesParamSortCreated,
+			Direction: github.ListIssuesParamDirectionDesc,
+		}).Next()
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		r.nextIssueNumber = 1
+		if issue != nil {
+			r.nextIssueNumber = issue.Number + 1
+		}
+	}
+	r.nextIssueNumber++
+	return r.nextIssueNumber - 1, nil
+}
+
+// milestoneNumber predicts the number of the next milestone.
+func (r *recorder) milestoneNumber(repo string) (int, error) {
+	if r.nextMilestoneNumber == 0 {
+		milestones, err := github.MilestonesToSlice(r.target.ListMilestones(repo, &github.ListMilestonesParams{
+			State: github.ListMilestonesParamStateAll,
+		}))
+		if err != nil {
+			return 0, err
+		}
+		r.nextMilestoneNumber = 1
+		for _, m := range milestones {
+			if r.nextMilestoneNumber <= m.Number {
+				r.nextMilestoneNumber = m.Number + 1
+			}
+		}
+	}
+	r.nextMilestoneNumber++
+	return r.nextMilestoneNumber - 1, nil
+}
+
+// projectNumber predicts the number of the next project.
+func (r *recorder) projectNumber(repo string) (int, error) {
+	if r.nextProjectNumber == 0 {
+		projects, err := github.ProjectsToSlice(r.target.ListProjects(repo, &github.ListProjectsParams{
+			State: github.ListProjectsParamStateAll,
+		}))
+		if err != nil && !strings.Contains(err.Error(), "Projects are disabled for this repository") {
+			return 0, err
+		}
+		r.nextProjectNumber = 1
+		for _, p := range projects {
+			if r.nextProjectNumber <= p.Number {
+				r.nextProjectNumber = p.Number + 1
+			}
+		}
+	}
+	r.nextProjectNumber++
+	return r.nextProjectNumber - 1, nil
+}
+
+func (r *recorder) UpdateRepo(_ string, params *github.UpdateRepoParams) (*github.Repo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateRepo"}, params); err != nil {
+		return nil, err
+	}
+	return &github.Repo{
+		Name:        params.Name,
+		Description: params.Description,
+		Homepage:    params.Homepage,
+		Private:     params.Private,
+	}, nil
+}
+
+func (r *recorder) CreateLabel(_ string, params *github.CreateLabelParams) (*github.Label, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "CreateLabel"}, params); err != nil {
+		return nil, err
+	}
+	return &github.Label{Name: params.Name, Description: params.Description, Color: params.Color}, nil
+}
+
+func (r *recorder) UpdateLabel(_, name string, params *github.UpdateLabelParams) (*github.Label, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateLabel", Name: name}, params); err != nil {
+		return nil, err
+	}
+	return &github.Label{Name: params.Name, Description: params.Description, Color: params.Color}, nil
+}
+
+func (r *recorder) DeleteLabel(_, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.record(&Operation{Method: "DeleteLabel", Name: name}, nil)
+}
+
+func (r *recorder) CreateIssue(repo string, params *github.CreateIssueParams) (*github.Issue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	number, err := r.issueNumber(repo)
+	if err != nil {
+		return nil, err
+	}
+	issue := &github.Issue{ID: r.newID(), Number: number, Title: params.Title, State: github.IssueStateOpen}
+	if err := r.record(&Operation{Method: "CreateIssue", Number: number, Result: issue.ID}, params); err != nil {
+		return nil, err
+	}
+	r.issues[number] = issue
+	return issue, nil
+}
+
+func (r *recorder) UpdateIssue(_ string, issueNumber int, params *github.UpdateIssueParams) (*github.Issue, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateIssue", Number: issueNumber}, params); err != nil {
+		return nil, err
+	}
+	issue := &github.Issue{Number: issueNumber, Title: params.Title, State: params.State}
+	if i, ok := r.issues[issueNumber]; ok {
+		issue = i
+		if params.State != 0 {
+			i.State = params.State
+		}
+	}
+	return issue, nil
+}
+
+func (r *recorder) AddAssignees(_ string, issueNumber int, assignees []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.record(&Operation{Method: "AddAssignees", Number: issueNumber}, assignees)
+}
+
+func (r *recorder) CreateComment(_ string, issueNumber int, body string) (*github.Comment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "CreateComment", Number: issueNumber},
+		map[string]string{"body": body}); err != nil {
+		return nil, err
+	}
+	return &github.Comment{Body: body}, nil
+}
+
+func (r *recorder) Import(repo string, params *github.Import) (*github.ImportResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	number, err := r.issueNumber(repo)
+	if err != nil {
+		return nil, err
+	}
+	issue := &github.Issue{ID: r.newID(), Number: number, Title: params.Issue.Title, State: github.IssueStateOpen}
+	if params.Issue.Closed {
+		issue.State = github.IssueStateClosed
+	}
+	if err := r.record(&Operation{Method: "Import", Number: number, Result: issue.ID}, params); err != nil {
+		return nil, err
+	}
+	r.issues[number] = issue
+	return &github.ImportResult{ID: issue.ID, Status: "pending"}, nil
+}
+
+func (r *recorder) CreateProject(repo string, params *github.CreateProjectParams) (*github.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	number, err := r.projectNumber(repo)
+	if err != nil {
+		return nil, err
+	}
+	project := &github.Project{
+		ID: r.newID(), Number: number, Name: params.Name, Body: params.Body,
+		State: github.ProjectStateOpen,
+	}
+	if err := r.record(&Operation{Method: "CreateProject", Number: number, Result: project.ID}, params); err != nil {
+		return nil, err
+	}
+	r.projects[project.ID] = project
+	r.projectIDs = append(r.projectIDs, project.ID)
+	return project, nil
+}
+
+func (r *recorder) UpdateProject(projectID int, params *github.UpdateProjectParams) (*github.Project, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateProject", ID: projectID}, params); err != nil {
+		return nil, err
+	}
+	project, ok := r.projects[projectID]
+	if !ok {
+		project = &github.Project{ID: projectID}
+	}
+	if params.Name != "" {
+		project.Name = params.Name
+	}
+	if params.Body != "" {
+		project.Body = params.Body
+	}
+	if params.State != 0 {
+		project.State = params.State
+	}
+	return project, nil
+}
+
+func (r *recorder) DeleteProject(projectID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "DeleteProject", ID: projectID}, nil); err != nil {
+		return err
+	}
+	r.deletedProjects[projectID] = true
+	return nil
+}
+
+func (r *recorder) CreateProjectColumn(projectID int, name string) (*github.ProjectColumn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	column := &github.ProjectColumn{ID: r.newID(), Name: name}
+	if err := r.record(&Operation{Method: "CreateProjectColumn", ID: projectID, Result: column.ID},
+		map[string]string{"name": name}); err != nil {
+		return nil, err
+	}
+	r.columns[projectID] = append(r.columns[projectID], column)
+	return column, nil
+}
+
+func (r *recorder) UpdateProjectColumn(projectColumnID int, name string) (*github.ProjectColumn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateProjectColumn", ID: projectColumnID},
+		map[string]string{"name": name}); err != nil {
+		return nil, err
+	}
+	return &github.ProjectColumn{ID: projectColumnID, Name: name}, nil
+}
+
+func (r *recorder) CreateProjectCard(columnID int, params *github.CreateProjectCardParams) (*github.ProjectCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	card := &github.ProjectCard{ID: r.newID(), Note: params.Note}
+	if err := r.record(&Operation{Method: "CreateProjectCard", ID: columnID, Result: card.ID}, params); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+func (r *recorder) UpdateProjectCard(projectCardID int, params *github.UpdateProjectCardParams) (*github.ProjectCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateProjectCard", ID: projectCardID}, params); err != nil {
+		return nil, err
+	}
+	return &github.ProjectCard{ID: projectCardID, Note: params.Note, Archived: params.Archived}, nil
+}
+
+func (r *recorder) MoveProjectCard(projectCardID int, params *github.MoveProjectCardParams) (*github.ProjectCard, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "MoveProjectCard", ID: projectCardID}, params); err != nil {
+		return nil, err
+	}
+	return &github.ProjectCard{ID: projectCardID}, nil
+}
+
+func (r *recorder) CreateMilestone(repo string, params *github.CreateMilestoneParams) (*github.Milestone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	number, err := r.milestoneNumber(repo)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.record(&Operation{Method: "CreateMilestone", Number: number}, params); err != nil {
+		return nil, err
+	}
+	milestone := &github.Milestone{
+		Number: number, Title: params.Title, Description: params.Description,
+		State: params.State, DueOn: params.DueOn,
+	}
+	r.milestones[number] = milestone
+	r.milestoneNumbers = append(r.milestoneNumbers, number)
+	return milestone, nil
+}
+
+func (r *recorder) UpdateMilestone(_ string, milestoneNumber int, params *github.UpdateMilestoneParams) (*github.Milestone, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateMilestone", Number: milestoneNumber}, params); err != nil {
+		return nil, err
+	}
+	milestone := &github.Milestone{
+		Number: milestoneNumber, Title: params.Title, Description: params.Description,
+		State: params.State, DueOn: params.DueOn,
+	}
+	r.milestones[milestoneNumber] = milestone
+	return milestone, nil
+}
+
+func (r *recorder) DeleteMilestone(_ string, milestoneNumber int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "DeleteMilestone", Number: milestoneNumber}, nil); err != nil {
+		return err
+	}
+	r.deletedMilestones[milestoneNumber] = true
+	return nil
+}
+
+func (r *recorder) CreateHook(_ string, params *github.CreateHookParams) (*github.Hook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hook := &github.Hook{ID: r.newID(), Name: params.Name, Active: params.Active, Events: params.Events, Config: params.Config}
+	if err := r.record(&Operation{Method: "CreateHook", Result: hook.ID}, params); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (r *recorder) UpdateHook(_ string, hookID int, params *github.UpdateHookParams) (*github.Hook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "UpdateHook", ID: hookID}, params); err != nil {
+		return nil, err
+	}
+	return &github.Hook{ID: hookID, Active: params.Active, Events: params.Events, Config: params.Config}, nil
+}
+
+func (r *recorder) CreateFile(repo, path string, params *github.CreateFileParams) (*github.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.record(&Operation{Method: "CreateFile", Repo: repo, Name: path}, params); err != nil {
+		return nil, err
+	}
+	return &github.File{Path: path}, nil
+}
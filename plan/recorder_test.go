@@ -0,0 +1,60 @@
+package plan
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+	"github.com/itchyny/github-migrator/repo"
+)
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	cli, err := NewRecorder(github.NewMockClient(
+		github.MockListIssues(func(string, *github.ListIssuesParams) github.Issues {
+			return github.IssuesFromSlice([]*github.Issue{{Number: 3}})
+		}),
+		github.MockListMilestones(func(string, *github.ListMilestonesParams) github.Milestones {
+			return github.MilestonesFromSlice([]*github.Milestone{{Number: 1, Title: "v1.0"}})
+		}),
+	), dir)
+	assert.Nil(t, err)
+	target := repo.New(cli, "example/test")
+
+	_, err = target.CreateLabel(&github.CreateLabelParams{Name: "bug", Color: "fc2929"})
+	assert.Nil(t, err)
+
+	milestone, err := target.CreateMilestone(&github.CreateMilestoneParams{Title: "v2.0"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, milestone.Number)
+	milestones, err := github.MilestonesToSlice(target.ListMilestones(&github.ListMilestonesParams{}))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"v1.0", "v2.0"}, []string{milestones[0].Title, milestones[1].Title})
+
+	res, err := target.Import(&github.Import{Issue: &github.ImportIssue{Title: "Example title 4"}})
+	assert.Nil(t, err)
+	res, err = target.GetImport(res.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "imported", res.Status)
+	issue, err := target.GetIssue(4)
+	assert.Nil(t, err)
+	assert.Equal(t, "Example title 4", issue.Title)
+	assert.True(t, issue.ID < 0)
+
+	names, ops, err := readOperations(dir)
+	assert.Nil(t, err)
+	for i, name := range names {
+		names[i] = filepath.Base(name)
+	}
+	assert.Equal(t, []string{
+		"00001-createlabel.json",
+		"00002-createmilestone-2.json",
+		"00003-import-4.json",
+	}, names)
+	assert.Equal(t, issue.ID, ops[2].Result)
+
+	_, err = NewRecorder(github.NewMockClient(), dir)
+	assert.EqualError(t, err, "directory is not empty: "+dir)
+}
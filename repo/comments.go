@@ -6,3 +6,8 @@ import "github.com/itchyny/github-migrator/github"
 func (r *Repo) ListComments(issueNumber int) github.Comments {
 	return r.cli.ListComments(r.path, issueNumber)
 }
+
+// CreateComment creates a new comment on the issue.
+func (r *Repo) CreateComment(issueNumber int, body string) (*github.Comment, error) {
+	return r.cli.CreateComment(r.path, issueNumber, body)
+}
@@ -28,3 +28,18 @@ func TestRepoListComments(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
+
+func TestRepoCreateComment(t *testing.T) {
+	expected := &github.Comment{
+		Body:    "Example body 1",
+		HTMLURL: "http://localhost/example/test/issues/1#issuecomment-1",
+	}
+	repo := New(github.NewMockClient(
+		github.MockCreateComment(func(string, int, string) (*github.Comment, error) {
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateComment(1, "Example body 1")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
@@ -0,0 +1,13 @@
+package repo
+
+import "github.com/itchyny/github-migrator/github"
+
+// CreateFile creates a file.
+func (r *Repo) CreateFile(path string, params *github.CreateFileParams) (*github.File, error) {
+	return r.cli.CreateFile(r.path, path, params)
+}
+
+// Download downloads the file with the credentials of the repository.
+func (r *Repo) Download(url string) ([]byte, error) {
+	return r.cli.Download(url)
+}
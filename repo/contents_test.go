@@ -0,0 +1,44 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestRepoCreateFile(t *testing.T) {
+	expected := &github.File{
+		Name:    "image.png",
+		Path:    "attachments/image.png",
+		HTMLURL: "http://localhost/example/assets/blob/main/attachments/image.png",
+	}
+	repo := New(github.NewMockClient(
+		github.MockCreateFile(func(path, name string, params *github.CreateFileParams) (*github.File, error) {
+			assert.Equal(t, "example/assets", path)
+			assert.Equal(t, "attachments/image.png", name)
+			assert.Equal(t, []byte("content"), params.Content)
+			return expected, nil
+		}),
+	), "example/assets")
+	got, err := repo.CreateFile("attachments/image.png", &github.CreateFileParams{
+		Message: "Add image.png",
+		Content: []byte("content"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoDownload(t *testing.T) {
+	expected := []byte("content")
+	repo := New(github.NewMockClient(
+		github.MockDownload(func(url string) ([]byte, error) {
+			assert.Equal(t, "http://localhost/storage/user/1/files/image.png", url)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.Download("http://localhost/storage/user/1/files/image.png")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
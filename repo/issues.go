@@ -1,6 +1,10 @@
 package repo
 
-import "github.com/itchyny/github-migrator/github"
+import (
+	"strconv"
+
+	"github.com/itchyny/github-migrator/github"
+)
 
 // ListIssues lists the issues.
 func (r *Repo) ListIssues() github.Issues {
@@ -11,11 +15,46 @@ func (r *Repo) ListIssues() github.Issues {
 	})
 }
 
+// ListIssuesByLabel lists the issues with the label.
+func (r *Repo) ListIssuesByLabel(name string) github.Issues {
+	return r.cli.ListIssues(r.path, &github.ListIssuesParams{
+		Filter:    github.ListIssuesParamFilterAll,
+		State:     github.ListIssuesParamStateAll,
+		Direction: github.ListIssuesParamDirectionAsc,
+		Labels:    name,
+	})
+}
+
+// ListIssuesByMilestone lists the issues in the milestone.
+func (r *Repo) ListIssuesByMilestone(milestoneNumber int) github.Issues {
+	return r.cli.ListIssues(r.path, &github.ListIssuesParams{
+		Filter:    github.ListIssuesParamFilterAll,
+		State:     github.ListIssuesParamStateAll,
+		Direction: github.ListIssuesParamDirectionAsc,
+		Milestone: strconv.Itoa(milestoneNumber),
+	})
+}
+
 // GetIssue gets the issue.
 func (r *Repo) GetIssue(issueNumber int) (*github.Issue, error) {
 	return r.cli.GetIssue(r.path, issueNumber)
 }
 
+// CreateIssue creates a new issue.
+func (r *Repo) CreateIssue(params *github.CreateIssueParams) (*github.Issue, error) {
+	return r.cli.CreateIssue(r.path, params)
+}
+
+// UpdateIssue updates the issue.
+func (r *Repo) UpdateIssue(issueNumber int, params *github.UpdateIssueParams) (*github.Issue, error) {
+	return r.cli.UpdateIssue(r.path, issueNumber, params)
+}
+
+// ListAssignees lists the users who can be assigned to the issues.
+func (r *Repo) ListAssignees() github.Users {
+	return r.cli.ListAssignees(r.path)
+}
+
 // AddAssignees assigns users to the issue.
 func (r *Repo) AddAssignees(issueNumber int, assignees []string) error {
 	return r.cli.AddAssignees(r.path, issueNumber, assignees)
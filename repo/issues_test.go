@@ -35,6 +35,46 @@ func TestRepoListIssues(t *testing.T) {
 	assert.Equal(t, got, expected)
 }
 
+func TestRepoListIssuesByLabel(t *testing.T) {
+	expected := []*github.Issue{
+		{
+			Number: 1,
+			Title:  "Example title 1",
+			Labels: []*github.Label{{Name: "good first issue"}},
+		},
+	}
+	repo := New(github.NewMockClient(
+		github.MockListIssues(func(_ string, params *github.ListIssuesParams) github.Issues {
+			assert.Equal(t, "good first issue", params.Labels)
+			assert.Equal(t, github.ListIssuesParamStateAll, params.State)
+			return github.IssuesFromSlice(expected)
+		}),
+	), "example/test")
+	got, err := github.IssuesToSlice(repo.ListIssuesByLabel("good first issue"))
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoListIssuesByMilestone(t *testing.T) {
+	expected := []*github.Issue{
+		{
+			Number:    1,
+			Title:     "Example title 1",
+			Milestone: &github.Milestone{Number: 2},
+		},
+	}
+	repo := New(github.NewMockClient(
+		github.MockListIssues(func(_ string, params *github.ListIssuesParams) github.Issues {
+			assert.Equal(t, "2", params.Milestone)
+			assert.Equal(t, github.ListIssuesParamStateAll, params.State)
+			return github.IssuesFromSlice(expected)
+		}),
+	), "example/test")
+	got, err := github.IssuesToSlice(repo.ListIssuesByMilestone(2))
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
 func TestRepoGetIssue(t *testing.T) {
 	expected := &github.Issue{
 		Number:  1,
@@ -55,3 +95,55 @@ func TestRepoGetIssue(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
+
+func TestRepoCreateIssue(t *testing.T) {
+	expected := &github.Issue{
+		Number: 1,
+		Title:  "Example title 1",
+		State:  github.IssueStateOpen,
+	}
+	repo := New(github.NewMockClient(
+		github.MockCreateIssue(func(string, *github.CreateIssueParams) (*github.Issue, error) {
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.CreateIssue(&github.CreateIssueParams{Title: "Example title 1"})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoUpdateIssue(t *testing.T) {
+	expected := &github.Issue{
+		Number: 1,
+		Title:  "Example title 1",
+		State:  github.IssueStateClosed,
+	}
+	repo := New(github.NewMockClient(
+		github.MockUpdateIssue(func(string, int, *github.UpdateIssueParams) (*github.Issue, error) {
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.UpdateIssue(1, &github.UpdateIssueParams{State: github.IssueStateClosed})
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestRepoListAssignees(t *testing.T) {
+	expected := []*github.User{
+		{
+			Login: "sample-user-1",
+		},
+		{
+			Login: "sample-user-2",
+		},
+	}
+	repo := New(github.NewMockClient(
+		github.MockListAssignees(func(path string) github.Users {
+			assert.Equal(t, "example/test", path)
+			return github.UsersFromSlice(expected)
+		}),
+	), "example/test")
+	got, err := github.UsersToSlice(repo.ListAssignees())
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
@@ -16,3 +16,8 @@ func (r *Repo) CreateLabel(params *github.CreateLabelParams) (*github.Label, err
 func (r *Repo) UpdateLabel(name string, params *github.UpdateLabelParams) (*github.Label, error) {
 	return r.cli.UpdateLabel(r.path, name, params)
 }
+
+// DeleteLabel deletes the label.
+func (r *Repo) DeleteLabel(name string) error {
+	return r.cli.DeleteLabel(r.path, name)
+}
@@ -78,3 +78,15 @@ func TestRepoUpdateLabel(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, got, expected)
 }
+
+func TestRepoDeleteLabel(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockDeleteLabel(func(path, name string) error {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, "good first issue", name)
+			return nil
+		}),
+	), "example/test")
+	err := repo.DeleteLabel("good first issue")
+	assert.Nil(t, err)
+}
@@ -0,0 +1,6 @@
+package repo
+
+// RenderMarkdown renders the markdown text to HTML.
+func (r *Repo) RenderMarkdown(text string) (string, error) {
+	return r.cli.RenderMarkdown(r.path, text)
+}
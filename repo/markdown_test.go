@@ -0,0 +1,23 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestRepoRenderMarkdown(t *testing.T) {
+	expected := "<p>Example <strong>body</strong></p>\n"
+	repo := New(github.NewMockClient(
+		github.MockRenderMarkdown(func(path, text string) (string, error) {
+			assert.Equal(t, "example/test", path)
+			assert.Equal(t, "Example **body**", text)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.RenderMarkdown("Example **body**")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
@@ -0,0 +1,18 @@
+package repo
+
+import "github.com/itchyny/github-migrator/github"
+
+// ListIssueReactions lists the reactions of the issue.
+func (r *Repo) ListIssueReactions(issueNumber int) github.Reactions {
+	return r.cli.ListIssueReactions(r.path, issueNumber)
+}
+
+// ListCommentReactions lists the reactions of the issue comment.
+func (r *Repo) ListCommentReactions(commentID int) github.Reactions {
+	return r.cli.ListCommentReactions(r.path, commentID)
+}
+
+// ListReviewCommentReactions lists the reactions of the review comment.
+func (r *Repo) ListReviewCommentReactions(commentID int) github.Reactions {
+	return r.cli.ListReviewCommentReactions(r.path, commentID)
+}
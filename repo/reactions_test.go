@@ -0,0 +1,58 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+var testReactions = []*github.Reaction{
+	{
+		ID:      1,
+		User:    &github.User{Login: "sample-user-1"},
+		Content: "+1",
+	},
+	{
+		ID:      2,
+		User:    &github.User{Login: "sample-user-2"},
+		Content: "heart",
+	},
+}
+
+func TestRepoListIssueReactions(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockListIssueReactions(func(_ string, issueNumber int) github.Reactions {
+			assert.Equal(t, 1, issueNumber)
+			return github.ReactionsFromSlice(testReactions)
+		}),
+	), "example/test")
+	got, err := github.ReactionsToSlice(repo.ListIssueReactions(1))
+	assert.Nil(t, err)
+	assert.Equal(t, got, testReactions)
+}
+
+func TestRepoListCommentReactions(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockListCommentReactions(func(_ string, commentID int) github.Reactions {
+			assert.Equal(t, 10, commentID)
+			return github.ReactionsFromSlice(testReactions)
+		}),
+	), "example/test")
+	got, err := github.ReactionsToSlice(repo.ListCommentReactions(10))
+	assert.Nil(t, err)
+	assert.Equal(t, got, testReactions)
+}
+
+func TestRepoListReviewCommentReactions(t *testing.T) {
+	repo := New(github.NewMockClient(
+		github.MockListReviewCommentReactions(func(_ string, commentID int) github.Reactions {
+			assert.Equal(t, 20, commentID)
+			return github.ReactionsFromSlice(testReactions)
+		}),
+	), "example/test")
+	got, err := github.ReactionsToSlice(repo.ListReviewCommentReactions(20))
+	assert.Nil(t, err)
+	assert.Equal(t, got, testReactions)
+}
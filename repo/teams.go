@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"strings"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+// ListTeams lists the teams of the organization.
+func (r *Repo) ListTeams() github.Teams {
+	return r.cli.ListTeams(strings.Split(r.path, "/")[0])
+}
+
+// GetTeam gets a team.
+func (r *Repo) GetTeam(org, slug string) (*github.Team, error) {
+	return r.cli.GetTeam(org, slug)
+}
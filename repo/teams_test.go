@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/itchyny/github-migrator/github"
+)
+
+func TestListTeams(t *testing.T) {
+	expected := []*github.Team{
+		{
+			ID:   1,
+			Name: "Team 1",
+			Slug: "team-1",
+		},
+		{
+			ID:   2,
+			Name: "Team 2",
+			Slug: "team-2",
+		},
+	}
+	repo := New(github.NewMockClient(
+		github.MockListTeams(func(org string) github.Teams {
+			assert.Equal(t, "example", org)
+			return github.TeamsFromSlice(expected)
+		}),
+	), "example/test")
+	got, err := github.TeamsToSlice(repo.ListTeams())
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}
+
+func TestGetTeam(t *testing.T) {
+	expected := &github.Team{
+		ID:   1,
+		Name: "Team 1",
+		Slug: "team-1",
+	}
+	repo := New(github.NewMockClient(
+		github.MockGetTeam(func(org, slug string) (*github.Team, error) {
+			assert.Equal(t, "other", org)
+			assert.Equal(t, "team-1", slug)
+			return expected, nil
+		}),
+	), "example/test")
+	got, err := repo.GetTeam("other", "team-1")
+	assert.Nil(t, err)
+	assert.Equal(t, got, expected)
+}